@@ -1,5 +1,11 @@
 package mock
 
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+)
+
 // An argument matcher it's an helper value that should be used when asserting
 // a mock was called with a specific set of values.
 //
@@ -26,3 +32,99 @@ func (ma MatchType[T]) Match(arg any) bool {
 	_, ok := arg.(T)
 	return ok
 }
+
+// MatchRegex it's an argument matcher that matches any string that matches the regular expression.
+// Use it on CalledWith or CalledWithExactly to match a string argument by a pattern.
+type MatchRegex struct {
+	Regexp *regexp.Regexp
+}
+
+func (mr MatchRegex) Match(arg any) bool {
+	s, ok := arg.(string)
+	return ok && mr.Regexp != nil && mr.Regexp.MatchString(s)
+}
+
+func (mr MatchRegex) String() string {
+	return fmt.Sprintf("MatchRegex(%s)", mr.Regexp)
+}
+
+// VariadicMatcher it's an argument matcher that matches the variadic arguments of a call, see MatchVariadic
+type VariadicMatcher struct {
+	args []any
+}
+
+// MatchVariadic returns an argument matcher that matches the variadic arguments of a call,
+// when they are exactly the specified arguments, in the same order (argument matchers are allowed).
+//
+// Use it as the last argument of CalledWithExactly or WithArgs to match the variadic arguments
+// regardless of how they were registered, either spread or as a single slice:
+//
+//	m.Method("Find").Assert(t).CalledWithExactly(ctx, mock.MatchVariadic(activeFilter, mock.MatchAny{}))
+//
+// It can also be used on any assertion to match a single slice argument
+func MatchVariadic(args ...any) VariadicMatcher {
+	return VariadicMatcher{args: args}
+}
+
+func (vm VariadicMatcher) Match(arg any) bool {
+	return vm.match(nil, arg)
+}
+
+func (vm VariadicMatcher) String() string {
+	return fmt.Sprintf("MatchVariadic(%s)", formatArgs(vm.args))
+}
+
+// match returns if the argument is a slice with the variadic arguments, comparing them with the equality
+func (vm VariadicMatcher) match(eq *equality, arg any) bool {
+	v := reflect.ValueOf(arg)
+	if v.Kind() != reflect.Slice {
+		return false
+	}
+
+	tail := make([]any, v.Len())
+	for i := range tail {
+		tail[i] = v.Index(i).Interface()
+	}
+
+	return vm.matchTail(eq, tail)
+}
+
+// matchTail returns if the variadic arguments of a call are the matcher arguments, comparing them with the equality
+func (vm VariadicMatcher) matchTail(eq *equality, tail []any) bool {
+	if len(tail) != len(vm.args) {
+		return false
+	}
+
+	for i, arg := range tail {
+		if !eq.argsAreEqual(vm.args[i], arg) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// matchReflectType it's an argument matcher that matches any value of the type t.
+// It's the equivalent of MatchType, for types that are only known at runtime
+type matchReflectType struct {
+	t reflect.Type
+}
+
+func (mt matchReflectType) Match(arg any) bool {
+	return arg != nil && reflect.TypeOf(arg) == mt.t
+}
+
+func (mt matchReflectType) String() string {
+	return fmt.Sprintf("MatchType[%s]", mt.t)
+}
+
+// hasMatcher returns if any of the args is an argument matcher
+func hasMatcher(args []any) bool {
+	for _, arg := range args {
+		if _, ok := arg.(ArgumentMatcher); ok {
+			return true
+		}
+	}
+
+	return false
+}
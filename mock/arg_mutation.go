@@ -0,0 +1,131 @@
+package mock
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/davecgh/go-spew/spew"
+)
+
+// watchedCall represents a call of a method that forbids argument mutation,
+// with the args that were passed to the mock and a copy of how they were at the time of the call
+type watchedCall struct {
+	seq        int
+	methodName string
+	args       []any
+	copies     []any
+}
+
+// argWatch identifies a test that forbids the argument mutation of a method
+type argWatch struct {
+	methodName string
+	t          *testing.T
+}
+
+var mutationDumpConfig = spew.ConfigState{
+	Indent:                  " ",
+	DisablePointerAddresses: true,
+	DisableCapacities:       true,
+	DisableMethods:          true,
+	SortKeys:                true,
+}
+
+// ForbidArgMutation makes the test fail if the arguments passed to the method are modified after the call,
+// which usually means that the code under test is changing the slices, maps or structs it was handed.
+//
+// The args of every method call made after this function is called are compared to how they were at the time of the call
+// when the test finishes, and the test fails with a diff of every modified argument.
+// The types that skip the argument copy (see SkipArgCopy) can't be checked
+func (m *Method) ForbidArgMutation(t *testing.T) {
+	if m.mock == nil {
+		return
+	}
+
+	s := m.mock.state()
+	if s.argWatches == nil {
+		s.argWatches = make(map[argWatch]bool)
+	}
+
+	watch := argWatch{methodName: m.name, t: t}
+	if s.argWatches[watch] {
+		return
+	}
+
+	s.argWatches[watch] = true
+	since := s.nextSeq
+	t.Cleanup(func() {
+		for _, msg := range m.argMutations(since) {
+			t.Error(msg)
+		}
+
+		m.unwatchArgs(watch)
+	})
+}
+
+// isWatched returns if any test forbids the argument mutation of the method
+func (s *mockState) isWatched(methodName string) bool {
+	for watch := range s.argWatches {
+		if watch.methodName == methodName {
+			return true
+		}
+	}
+
+	return false
+}
+
+// unwatchArgs removes the watch of a test, dropping the watched calls of the method once no test watches it
+func (m *Method) unwatchArgs(watch argWatch) {
+	s := m.mock.state()
+	delete(s.argWatches, watch)
+	if s.isWatched(m.name) {
+		return
+	}
+
+	calls := []watchedCall{}
+	for _, call := range s.watchedCalls {
+		if call.methodName != m.name {
+			calls = append(calls, call)
+		}
+	}
+	s.watchedCalls = calls
+}
+
+// watchArgs keeps the args of a method call to check if they are modified later,
+// if the method forbids argument mutation
+func (mock *Mock) watchArgs(seq int, methodName string, args []any) {
+	s := mock.state()
+	if !s.isWatched(methodName) {
+		return
+	}
+
+	s.watchedCalls = append(s.watchedCalls, watchedCall{
+		seq:        seq,
+		methodName: methodName,
+		args:       args,
+		copies:     copyValues(args),
+	})
+}
+
+// argMutations returns a failure message for every argument of the method calls registered from the 'since' sequence number
+// that was modified after the call
+func (m *Method) argMutations(since int) (msgs []string) {
+	n := 0
+	for _, call := range m.mock.state().watchedCalls {
+		if call.methodName != m.name || call.seq < since {
+			continue
+		}
+
+		n++
+		for i := range call.args {
+			expected := mutationDumpConfig.Sdump(call.copies[i])
+			actual := mutationDumpConfig.Sdump(call.args[i])
+
+			if diff := mountSnapshotDiff(expected, actual); diff != "" {
+				msg := fmt.Sprintf("Failed to assert mock arguments.\nThe argument %d of the call %d of the method %s was modified after being passed to the mock:\n%s", i, n, m.fullName(), diff)
+				msgs = append(msgs, msg)
+			}
+		}
+	}
+
+	return
+}
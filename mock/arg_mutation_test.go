@@ -0,0 +1,98 @@
+package mock
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestForbidArgMutation(t *testing.T) {
+	t.Run("Should report the arguments modified after the call", func(t *testing.T) {
+		m := NewNamedMock("orderRepo")
+		m.Method("Save").ForbidArgMutation(t)
+		items := []string{"i1", "i2"}
+		o := &order{ID: "o1"}
+
+		m.RegisterMethodCall("Save", "o1", items)
+		m.RegisterMethodCall("Save", "o2", o)
+		items[1] = "changed"
+		o.ID = "changed"
+
+		assert.Equal(t, []string{
+			"Failed to assert mock arguments.\n" +
+				"The argument 1 of the call 1 of the method orderRepo.Save was modified after being passed to the mock:\n" +
+				"--- Expected\n" +
+				"+++ Actual\n" +
+				"@@ -1,5 +1,5 @@\n" +
+				" ([]string) (len=2) {\n" +
+				"  (string) (len=2) \"i1\",\n" +
+				"- (string) (len=2) \"i2\"\n" +
+				"+ (string) (len=7) \"changed\"\n" +
+				" }\n" +
+				" \n",
+			"Failed to assert mock arguments.\n" +
+				"The argument 1 of the call 2 of the method orderRepo.Save was modified after being passed to the mock:\n" +
+				"--- Expected\n" +
+				"+++ Actual\n" +
+				"@@ -1,4 +1,4 @@\n" +
+				" (*mock.order)({\n" +
+				"- ID: (string) (len=2) \"o1\",\n" +
+				"+ ID: (string) (len=7) \"changed\",\n" +
+				"  Items: ([]string) <nil>,\n" +
+				"  notes: (map[string]string) <nil>,\n",
+		}, m.Method("Save").argMutations(0))
+
+		items[1] = "i2"
+		o.ID = "o1"
+	})
+	t.Run("Should not report the arguments that were not modified", func(t *testing.T) {
+		m := NewMock()
+		m.Method("Save").ForbidArgMutation(t)
+		m.Method("Save").ForbidArgMutation(t)
+		items := []string{"i1"}
+
+		m.RegisterMethodCall("Save", items)
+		m.RegisterMethodCall("Delete", items)
+		m.Reset()
+		m.RegisterMethodCall("Save", items)
+
+		assert.Empty(t, m.Method("Save").argMutations(0))
+		assert.Equal(t, 2, len(m.state().watchedCalls))
+	})
+	t.Run("Should watch the arguments for each test that shares the mock", func(t *testing.T) {
+		m := NewMock()
+		items := []string{"i1"}
+
+		t.Run("first", func(t *testing.T) {
+			m.Method("Save").ForbidArgMutation(t)
+			m.RegisterMethodCall("Save", items)
+		})
+		assert.Empty(t, m.state().argWatches)
+		assert.Empty(t, m.state().watchedCalls)
+
+		t.Run("second", func(t *testing.T) {
+			m.RegisterMethodCall("Save", items)
+			since := m.state().nextSeq
+			m.Method("Save").ForbidArgMutation(t)
+			m.RegisterMethodCall("Save", items)
+			items[0] = "changed"
+
+			assert.True(t, m.state().argWatches[argWatch{methodName: "Save", t: t}])
+			assert.Equal(t, 1, len(m.Method("Save").argMutations(since)))
+
+			items[0] = "i1"
+		})
+		assert.Empty(t, m.state().argWatches)
+		assert.Empty(t, m.state().watchedCalls)
+	})
+	t.Run("Should only consider the methods that forbid argument mutation", func(t *testing.T) {
+		m := NewMock()
+		items := []string{"i1"}
+
+		m.RegisterMethodCall("Save", items)
+		items[0] = "changed"
+
+		assert.Empty(t, m.Method("Save").argMutations(0))
+		assert.Empty(t, m.state().watchedCalls)
+	})
+}
@@ -0,0 +1,87 @@
+package mock
+
+import "testing"
+
+// Checkpoint represents a point in the mock calls history,
+// and filters the mock use information to the calls registered after it
+type Checkpoint struct {
+	mock *Mock
+	// since is the sequence number of the first mock call considered by the checkpoint
+	since int
+}
+
+// GetCalls returns the mock calls registered after the checkpoint
+func (c Checkpoint) GetCalls() []MockCall {
+	if c.mock == nil {
+		return []MockCall{}
+	}
+
+	return c.mock.callsSince(c.since)
+}
+
+// equality returns how the mock compares values
+func (c Checkpoint) equality() *equality {
+	if c.mock == nil {
+		return nil
+	}
+
+	return c.mock.state().equality
+}
+
+// Called returns if the mock was called after the checkpoint
+func (c Checkpoint) Called() bool {
+	return len(c.GetCalls()) > 0
+}
+
+// CalledOnce returns if the mock was called exactly once after the checkpoint
+func (c Checkpoint) CalledOnce() bool {
+	return len(c.GetCalls()) == 1
+}
+
+// CalledTimes returns if the mock was called 'n' times after the checkpoint
+func (c Checkpoint) CalledTimes(n int) bool {
+	return len(c.GetCalls()) == n
+}
+
+// CalledWith returns if the mock was called at least once with the specified arguments after the checkpoint
+func (c Checkpoint) CalledWith(args ...any) bool {
+	return checkCalledWith(c.equality(), c.GetCalls(), args...)
+}
+
+// CalledWithExactly returns if the mock was called at least once with exactly the specified arguments after the checkpoint,
+// with the same values and in the same order
+func (c Checkpoint) CalledWithExactly(args ...any) bool {
+	return checkCalledWithExactly(c.equality(), c.GetCalls(), args...)
+}
+
+// CalledWithInOrder returns if the mock was called at least once with the specified arguments in the same order after the checkpoint,
+// but not necessarily next to each other
+func (c Checkpoint) CalledWithInOrder(args ...any) bool {
+	return checkCalledWithInOrder(c.equality(), c.GetCalls(), args...)
+}
+
+// Method filters the use information after the checkpoint for a specific method
+func (c Checkpoint) Method(name string) *Method {
+	if c.mock == nil {
+		return &Method{name: name, since: c.since}
+	}
+
+	m := c.mock.Method(name)
+	m.since = c.since
+
+	return m
+}
+
+// Assert will begin a new assertion for the mock, considering only the calls registered after the checkpoint
+func (c Checkpoint) Assert(t *testing.T) *MockAssertion {
+	m := c.mock
+	if m == nil {
+		m = &Mock{}
+	}
+
+	return &MockAssertion{
+		t:     t,
+		m:     m,
+		since: c.since,
+	}
+}
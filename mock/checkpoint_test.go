@@ -0,0 +1,114 @@
+package mock
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckpoint(t *testing.T) {
+	t.Run("Should only consider the calls registered after the checkpoint", func(t *testing.T) {
+		m := NewMock()
+		m.RegisterMethodCall("Get", "u1")
+		m.RegisterMethodCall("Save", "u1")
+
+		c := m.Checkpoint()
+		assert.Empty(t, c.GetCalls())
+		assert.False(t, c.Called())
+
+		m.RegisterMethodCall("Get", "u2")
+
+		assert.Equal(t, []MockCall{{MethodName: "Get", Args: []any{"u2"}}}, c.GetCalls())
+		assert.True(t, c.Called())
+		assert.True(t, c.CalledOnce())
+		assert.True(t, c.CalledTimes(1))
+		assert.True(t, c.CalledWith("u2"))
+		assert.False(t, c.CalledWith("u1"))
+		assert.True(t, c.CalledWithExactly("u2"))
+	})
+	t.Run("Should filter the method calls after the checkpoint", func(t *testing.T) {
+		m := NewMock()
+		m.RegisterMethodCall("Get", "u1")
+
+		c := m.Checkpoint()
+		method := c.Method("Get")
+		assert.False(t, method.Called())
+
+		m.RegisterMethodCall("Get", "u2")
+		m.RegisterMethodCall("Save", "u2")
+
+		assert.True(t, method.CalledOnce())
+		assert.True(t, method.CalledWith("u2"))
+		assert.False(t, method.CalledWith("u1"))
+		assert.True(t, m.Method("Get").CalledTimes(2))
+	})
+	t.Run("Should make assertions considering only the calls after the checkpoint", func(t *testing.T) {
+		m := NewMock()
+		m.RegisterMethodCall("Get", "u1")
+
+		c := m.Checkpoint()
+		m.RegisterMethodCall("Get", "u2")
+
+		c.Assert(t).
+			CalledOnce().
+			And().
+			CalledWith("u2").
+			And().Not().
+			CalledWith("u1").
+			And().
+			NoMoreInteractions()
+		c.Method("Get").Assert(t).CalledOnce()
+
+		assert.False(t, m.state().isVerified(0))
+		assert.True(t, m.state().isVerified(1))
+	})
+	t.Run("Should not break if the calls were reset after the checkpoint", func(t *testing.T) {
+		m := NewMock()
+		m.RegisterMethodCall("Get", "u1")
+
+		c := m.Checkpoint()
+		m.ResetCalls()
+
+		assert.Empty(t, c.GetCalls())
+		assert.False(t, c.Method("Get").Called())
+	})
+	t.Run("Should consider the calls registered after the checkpoint, even after resetting the calls", func(t *testing.T) {
+		m := NewMock()
+		m.RegisterMethodCall("A", "u1")
+		m.RegisterMethodCall("A", "u2")
+
+		c := m.Checkpoint()
+		m.ResetCalls()
+		m.RegisterMethodCall("B")
+
+		assert.True(t, c.Method("B").Called())
+		assert.Equal(t, []MockCall{{MethodName: "B"}}, c.GetCalls())
+
+		m.RegisterMethodCall("A", "u3")
+		c = m.Checkpoint()
+		m.Method("A").ResetCalls()
+		m.RegisterMethodCall("C")
+
+		assert.Equal(t, []MockCall{{MethodName: "C"}}, c.GetCalls())
+		c.Assert(t).CalledOnce()
+	})
+	t.Run("Should be able to store the checkpoint", func(t *testing.T) {
+		m := NewMock()
+		suite := struct {
+			checkpoint Checkpoint
+		}{checkpoint: m.Checkpoint()}
+
+		m.RegisterMethodCall("A")
+
+		assert.True(t, suite.checkpoint.Called())
+	})
+	t.Run("Should not break on the zero value", func(t *testing.T) {
+		var c Checkpoint
+
+		assert.False(t, c.Called())
+		assert.Empty(t, c.Method("Get").GetCalls())
+		assert.False(t, c.Method("Get").Called())
+		c.Method("Get").Assert(t).Not().Called()
+		c.Assert(t).Not().Called()
+	})
+}
@@ -0,0 +1,287 @@
+package mock
+
+import (
+	"math"
+	"math/big"
+	"reflect"
+	"sync"
+	"unsafe"
+)
+
+var (
+	comparersMu sync.RWMutex
+	comparers   = map[reflect.Type]func(a, b any) bool{}
+)
+
+func init() {
+	RegisterComparer(func(a, b *big.Int) bool { return a.Cmp(b) == 0 })
+	RegisterComparer(func(a, b *big.Float) bool { return a.Cmp(b) == 0 })
+	RegisterComparer(func(a, b *big.Rat) bool { return a.Cmp(b) == 0 })
+}
+
+// Comparer represents a function that compares two values of a type
+type Comparer struct {
+	t     reflect.Type
+	equal func(a, b any) bool
+}
+
+// ComparerFor returns a comparer for the values of the type T, that can be set on a mock with SetComparers
+func ComparerFor[T any](equal func(a, b T) bool) Comparer {
+	return Comparer{
+		t: reflect.TypeOf((*T)(nil)).Elem(),
+		equal: func(a, b any) bool {
+			return equal(a.(T), b.(T))
+		},
+	}
+}
+
+// RegisterComparer registers a function that every mock uses to compare the values of the type T,
+// when matching the call arguments on the assertions and on the responses specified with args:
+//
+//	mock.RegisterComparer(func(a, b decimal.Decimal) bool {
+//		return a.Equal(b)
+//	})
+//
+// The values are compared with reflect.DeepEqual by default, except for the types that implement an `Equal(T) bool` method,
+// that are compared with it (like time.Time), and for big.Int, big.Float and big.Rat pointers, that are compared by their values.
+//
+// T must be a concrete type, and the comparers set on a mock (see SetComparers) have priority over the registered ones
+func RegisterComparer[T any](equal func(a, b T) bool) {
+	c := ComparerFor(equal)
+
+	comparersMu.Lock()
+	defer comparersMu.Unlock()
+
+	comparers[c.t] = c.equal
+}
+
+// equality represents how a mock compares values
+type equality struct {
+	comparers      map[reflect.Type]func(a, b any) bool
+	floatTolerance float64
+}
+
+// copy returns an independent copy of the equality
+func (eq *equality) copy() *equality {
+	c := &equality{floatTolerance: eq.floatTolerance}
+	if eq.comparers != nil {
+		c.comparers = make(map[reflect.Type]func(a, b any) bool, len(eq.comparers))
+		for t, equal := range eq.comparers {
+			c.comparers[t] = equal
+		}
+	}
+
+	return c
+}
+
+// SetComparers sets comparers that the mock uses to compare the values of their types,
+// with priority over the comparers registered with RegisterComparer:
+//
+//	m.SetComparers(mock.ComparerFor(func(a, b time.Time) bool {
+//		return a.Truncate(time.Second).Equal(b.Truncate(time.Second))
+//	}))
+func (mock *Mock) SetComparers(comparers ...Comparer) {
+	eq := mock.state().equality
+	if eq.comparers == nil {
+		eq.comparers = make(map[reflect.Type]func(a, b any) bool)
+	}
+
+	for _, c := range comparers {
+		eq.comparers[c.t] = c.equal
+	}
+}
+
+// SetFloatTolerance makes the mock consider two float values equal when their difference is within the tolerance
+func (mock *Mock) SetFloatTolerance(tolerance float64) {
+	mock.state().equality.floatTolerance = tolerance
+}
+
+// comparer returns the comparer for the type t, if there's one
+func (eq *equality) comparer(t reflect.Type) (func(a, b any) bool, bool) {
+	if eq != nil {
+		if c, ok := eq.comparers[t]; ok {
+			return c, true
+		}
+	}
+
+	comparersMu.RLock()
+	defer comparersMu.RUnlock()
+
+	c, ok := comparers[t]
+	return c, ok
+}
+
+// argsAreEqual matches two mock arguments to see if they are equal.
+// matchArg its the value to match.
+// usedArg its the argument that was actually used in the mock call.
+//
+// A nil equality only considers the comparers registered with RegisterComparer
+func (eq *equality) argsAreEqual(matchArg, usedArg any) bool {
+	if vm, ok := matchArg.(VariadicMatcher); ok {
+		return vm.match(eq, usedArg)
+	}
+
+	matcher, ok := matchArg.(ArgumentMatcher)
+	if ok {
+		return matcher.Match(usedArg)
+	}
+
+	return eq.deepEqual(reflect.ValueOf(matchArg), reflect.ValueOf(usedArg), map[comparedPointers]bool{})
+}
+
+// comparedPointers identifies two pointers that are being compared, to stop on cycles
+type comparedPointers struct {
+	x, y unsafe.Pointer
+	t    reflect.Type
+}
+
+// deepEqual works like reflect.DeepEqual, but it uses the comparers, the Equal methods,
+// and the float tolerance, whenever they apply to the compared values
+func (eq *equality) deepEqual(x, y reflect.Value, visited map[comparedPointers]bool) bool {
+	if !x.IsValid() || !y.IsValid() {
+		return x.IsValid() == y.IsValid()
+	}
+	if x.Type() != y.Type() {
+		return false
+	}
+
+	t := x.Type()
+	switch t.Kind() {
+	case reflect.Pointer, reflect.Map, reflect.Slice, reflect.Interface, reflect.Func, reflect.Chan:
+		if x.IsNil() || y.IsNil() {
+			return x.IsNil() && y.IsNil()
+		}
+	}
+
+	if c, ok := eq.comparer(t); ok {
+		return c(x.Interface(), y.Interface())
+	}
+	if m, ok := equalMethod(t); ok {
+		return x.Method(m.Index).Call([]reflect.Value{y})[0].Bool()
+	}
+
+	switch t.Kind() {
+	case reflect.Float32, reflect.Float64:
+		if eq != nil && eq.floatTolerance > 0 {
+			return math.Abs(x.Float()-y.Float()) <= eq.floatTolerance
+		}
+
+		return x.Float() == y.Float()
+	case reflect.Array:
+		for i := 0; i < x.Len(); i++ {
+			if !eq.deepEqual(x.Index(i), y.Index(i), visited) {
+				return false
+			}
+		}
+
+		return true
+	case reflect.Slice:
+		if x.Len() != y.Len() {
+			return false
+		}
+		if x.UnsafePointer() == y.UnsafePointer() {
+			return true
+		}
+
+		for i := 0; i < x.Len(); i++ {
+			if !eq.deepEqual(x.Index(i), y.Index(i), visited) {
+				return false
+			}
+		}
+
+		return true
+	case reflect.Interface:
+		return eq.deepEqual(x.Elem(), y.Elem(), visited)
+	case reflect.Pointer:
+		if x.UnsafePointer() == y.UnsafePointer() {
+			return true
+		}
+
+		key := comparedPointers{x: x.UnsafePointer(), y: y.UnsafePointer(), t: t}
+		if visited[key] {
+			return true
+		}
+		visited[key] = true
+
+		return eq.deepEqual(x.Elem(), y.Elem(), visited)
+	case reflect.Struct:
+		x, y = addressable(x), addressable(y)
+		for i := 0; i < t.NumField(); i++ {
+			if !eq.deepEqual(exportedField(x, i), exportedField(y, i), visited) {
+				return false
+			}
+		}
+
+		return true
+	case reflect.Map:
+		if x.Len() != y.Len() {
+			return false
+		}
+		if x.UnsafePointer() == y.UnsafePointer() {
+			return true
+		}
+
+		key := comparedPointers{x: x.UnsafePointer(), y: y.UnsafePointer(), t: t}
+		if visited[key] {
+			return true
+		}
+		visited[key] = true
+
+		iter := x.MapRange()
+		for iter.Next() {
+			val := y.MapIndex(iter.Key())
+			if !val.IsValid() || !eq.deepEqual(iter.Value(), val, visited) {
+				return false
+			}
+		}
+
+		return true
+	case reflect.Func:
+		// the functions are kept as they are on the copied arguments, so they are compared by their code pointer
+		return x.UnsafePointer() == y.UnsafePointer()
+	default:
+		return x.Equal(y)
+	}
+}
+
+// equalMethod returns the `Equal(T) bool` method of the type T, if it has one
+func equalMethod(t reflect.Type) (reflect.Method, bool) {
+	if t.Kind() == reflect.Interface {
+		return reflect.Method{}, false
+	}
+
+	m, ok := t.MethodByName("Equal")
+	if !ok {
+		return m, false
+	}
+
+	mt := m.Type
+	if mt.NumIn() != 2 || mt.In(1) != t || mt.NumOut() != 1 || mt.Out(0).Kind() != reflect.Bool {
+		return m, false
+	}
+
+	return m, true
+}
+
+// addressable returns an addressable copy of v, if v is not addressable
+func addressable(v reflect.Value) reflect.Value {
+	if v.CanAddr() {
+		return v
+	}
+
+	c := reflect.New(v.Type()).Elem()
+	c.Set(v)
+
+	return c
+}
+
+// exportedField returns the field 'i' of the addressable struct v,
+// in a way that it can be used even if the field is unexported
+func exportedField(v reflect.Value, i int) reflect.Value {
+	f := v.Field(i)
+	if v.Type().Field(i).IsExported() {
+		return f
+	}
+
+	return reflect.NewAt(f.Type(), unsafe.Pointer(f.UnsafeAddr())).Elem()
+}
@@ -0,0 +1,130 @@
+package mock
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type caseInsensitive string
+
+type event struct {
+	Name string
+	at   time.Time
+}
+
+func TestArgsAreEqual(t *testing.T) {
+	t.Run("Should compare the values with their Equal method", func(t *testing.T) {
+		now := time.Now()
+		utc := now.UTC()
+
+		assert.True(t, (*equality)(nil).argsAreEqual(now, utc))
+		assert.True(t, (*equality)(nil).argsAreEqual(now.Round(0), now))
+		assert.True(t, (*equality)(nil).argsAreEqual(event{Name: "e1", at: now}, event{Name: "e1", at: utc}))
+		assert.True(t, (*equality)(nil).argsAreEqual([]any{&event{at: now}}, []any{&event{at: utc}}))
+		assert.False(t, (*equality)(nil).argsAreEqual(now, now.Add(time.Second)))
+	})
+	t.Run("Should compare the big numbers by their values", func(t *testing.T) {
+		assert.True(t, (*equality)(nil).argsAreEqual(big.NewInt(42), big.NewInt(42)))
+		assert.True(t, (*equality)(nil).argsAreEqual(big.NewRat(1, 2), big.NewRat(2, 4)))
+		assert.False(t, (*equality)(nil).argsAreEqual(big.NewInt(42), big.NewInt(43)))
+	})
+	t.Run("Should compare like reflect.DeepEqual otherwise", func(t *testing.T) {
+		assert.True(t, (*equality)(nil).argsAreEqual(map[string][]int{"a": {1}}, map[string][]int{"a": {1}}))
+		assert.True(t, (*equality)(nil).argsAreEqual(nil, nil))
+		assert.False(t, (*equality)(nil).argsAreEqual(nil, (*order)(nil)))
+		assert.False(t, (*equality)(nil).argsAreEqual(int32(1), int64(1)))
+		assert.False(t, (*equality)(nil).argsAreEqual([]int{}, []int(nil)))
+		assert.False(t, (*equality)(nil).argsAreEqual(order{notes: map[string]string{"a": "b"}}, order{}))
+	})
+	t.Run("Should stop on cycles", func(t *testing.T) {
+		o1, o2 := &order{ID: "o"}, &order{ID: "o"}
+		o1.next, o2.next = o1, o2
+
+		assert.True(t, (*equality)(nil).argsAreEqual(o1, o2))
+	})
+}
+
+func TestComparers(t *testing.T) {
+	RegisterComparer(func(a, b caseInsensitive) bool {
+		return strings.EqualFold(string(a), string(b))
+	})
+
+	t.Run("Should use the registered comparers", func(t *testing.T) {
+		m := NewMock()
+		m.RegisterMethodCall("GetUser", caseInsensitive("JOHN"), []caseInsensitive{"Jane"})
+
+		assert.True(t, m.CalledWith(caseInsensitive("john")))
+		assert.True(t, m.Method("GetUser").CalledWithExactly(caseInsensitive("john"), []caseInsensitive{"JANE"}))
+		assert.True(t, m.GetCalls()[0].HasArgument(caseInsensitive("john")))
+		assert.False(t, m.CalledWith(caseInsensitive("jo")))
+	})
+	t.Run("Should give priority to the mock comparers", func(t *testing.T) {
+		m := NewMock()
+		m.SetComparers(ComparerFor(func(a, b caseInsensitive) bool {
+			return a == b
+		}))
+		m.RegisterMethodCall("GetUser", caseInsensitive("JOHN"))
+
+		other := NewMock()
+		other.RegisterMethodCall("GetUser", caseInsensitive("JOHN"))
+
+		assert.False(t, m.CalledWith(caseInsensitive("john")))
+		assert.True(t, other.CalledWith(caseInsensitive("john")))
+	})
+	t.Run("Should use the comparers to choose the response", func(t *testing.T) {
+		m := NewMock()
+		m.SetComparers(ComparerFor(func(a, b time.Time) bool {
+			return a.Truncate(time.Hour).Equal(b.Truncate(time.Hour))
+		}))
+		day := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+		m.Method("GetEvents").WithArgs(day).Returns("day events")
+		m.Method("GetEvents").WithArgs(MatchAny{}).Returns("any events")
+
+		assert.Equal(t, "day events", m.GetResponseAndRegister("GetEvents", day.Add(time.Minute)).GetString(0))
+		assert.Equal(t, "any events", m.GetResponseAndRegister("GetEvents", day.Add(time.Hour)).GetString(0))
+		assert.Empty(t, m.UnusedStubs())
+	})
+	t.Run("Should choose the response specified for equal pointers", func(t *testing.T) {
+		m := NewMock()
+		m.Method("Save").WithArgs(&order{ID: "o1"}).Returns("saved")
+
+		assert.Equal(t, "saved", m.GetResponseAndRegister("Save", &order{ID: "o1"}).GetString(0))
+		assert.True(t, m.GetResponseAndRegister("Save", &order{ID: "o2"}).IsEmpty())
+	})
+	t.Run("Should keep the comparers on reset and clone", func(t *testing.T) {
+		m := NewMock()
+		m.SetFloatTolerance(0.1)
+		m.Reset()
+		c := m.Clone()
+		c.SetFloatTolerance(0)
+
+		m.RegisterMethodCall("SetPrice", 10.05)
+		c.RegisterMethodCall("SetPrice", 10.05)
+
+		assert.True(t, m.CalledWith(10.0))
+		assert.False(t, c.CalledWith(10.0))
+	})
+}
+
+func TestFloatTolerance(t *testing.T) {
+	t.Run("Should consider the floats within the tolerance equal", func(t *testing.T) {
+		m := NewMock()
+		m.SetFloatTolerance(0.01)
+		a, b := 0.1, 0.2
+		m.RegisterMethodCall("SetPrice", a+b, []float32{1.001})
+
+		assert.True(t, m.CalledWithExactly(0.3, []float32{1}))
+		assert.False(t, m.CalledWith(0.32))
+	})
+	t.Run("Should compare the floats exactly by default", func(t *testing.T) {
+		m := NewMock()
+		a, b := 0.1, 0.2
+		m.RegisterMethodCall("SetPrice", a+b)
+
+		assert.False(t, m.CalledWith(0.3))
+	})
+}
@@ -0,0 +1,153 @@
+package mock
+
+import (
+	"context"
+	"reflect"
+	"sync"
+	"time"
+	"unsafe"
+)
+
+var (
+	uncopiedTypesMu sync.RWMutex
+	uncopiedTypes   = map[reflect.Type]bool{}
+
+	contextType = reflect.TypeOf((*context.Context)(nil)).Elem()
+)
+
+func init() {
+	SkipArgCopy[time.Location]()
+}
+
+// SkipArgCopy makes the mocks keep the values of the type T as they were passed, instead of copying them,
+// when registering the method calls.
+//
+// The mocks copy the arguments of every registered call, so the calls reflect what was actually passed,
+// even if the code under test changes the arguments after the call.
+// Skip the copy of types that must be compared by their identity,
+// or that can't be copied (like types that hold resources).
+//
+// The values that implement context.Context are never copied
+func SkipArgCopy[T any]() {
+	t := reflect.TypeOf((*T)(nil)).Elem()
+
+	uncopiedTypesMu.Lock()
+	defer uncopiedTypesMu.Unlock()
+
+	uncopiedTypes[t] = true
+}
+
+// skipsCopy returns if the values of the type t should not be copied
+func skipsCopy(t reflect.Type) bool {
+	if t.Implements(contextType) {
+		return true
+	}
+
+	uncopiedTypesMu.RLock()
+	defer uncopiedTypesMu.RUnlock()
+
+	return uncopiedTypes[t]
+}
+
+// copiedPointer identifies a pointer that was already copied
+type copiedPointer struct {
+	ptr unsafe.Pointer
+	t   reflect.Type
+}
+
+// copyValues returns a deep copy of the values, like the call args or the response values
+func copyValues(values []any) []any {
+	if values == nil {
+		return nil
+	}
+
+	copied := make(map[copiedPointer]reflect.Value)
+	c := make([]any, len(values))
+	for i, val := range values {
+		if val == nil {
+			continue
+		}
+
+		c[i] = deepCopy(reflect.ValueOf(val), copied).Interface()
+	}
+
+	return c
+}
+
+// deepCopy returns a deep copy of the value v, including its unexported fields.
+//
+// The pointers already copied are reused, so cycles and shared pointers are kept.
+// Functions, channels, map keys and the types that skip the copy are kept as they are
+func deepCopy(v reflect.Value, copied map[copiedPointer]reflect.Value) reflect.Value {
+	t := v.Type()
+	if skipsCopy(t) {
+		return v
+	}
+
+	switch v.Kind() {
+	case reflect.Pointer:
+		if v.IsNil() || skipsCopy(t.Elem()) {
+			return v
+		}
+
+		key := copiedPointer{ptr: v.UnsafePointer(), t: t}
+		if c, ok := copied[key]; ok {
+			return c
+		}
+
+		c := reflect.New(t.Elem())
+		copied[key] = c
+		c.Elem().Set(deepCopy(v.Elem(), copied))
+
+		return c
+	case reflect.Interface:
+		if v.IsNil() {
+			return v
+		}
+
+		c := reflect.New(t).Elem()
+		c.Set(deepCopy(v.Elem(), copied))
+
+		return c
+	case reflect.Slice:
+		if v.IsNil() {
+			return v
+		}
+
+		c := reflect.MakeSlice(t, v.Len(), v.Len())
+		for i := 0; i < v.Len(); i++ {
+			c.Index(i).Set(deepCopy(v.Index(i), copied))
+		}
+
+		return c
+	case reflect.Array:
+		c := reflect.New(t).Elem()
+		for i := 0; i < v.Len(); i++ {
+			c.Index(i).Set(deepCopy(v.Index(i), copied))
+		}
+
+		return c
+	case reflect.Map:
+		if v.IsNil() {
+			return v
+		}
+
+		c := reflect.MakeMapWithSize(t, v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			c.SetMapIndex(iter.Key(), deepCopy(iter.Value(), copied))
+		}
+
+		return c
+	case reflect.Struct:
+		src := addressable(v)
+		c := reflect.New(t).Elem()
+		for i := 0; i < t.NumField(); i++ {
+			exportedField(c, i).Set(deepCopy(exportedField(src, i), copied))
+		}
+
+		return c
+	default:
+		return v
+	}
+}
@@ -0,0 +1,114 @@
+package mock
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type order struct {
+	ID    string
+	Items []string
+	notes map[string]string
+	next  *order
+}
+
+type handle struct {
+	Name string
+}
+
+type svcCfg struct {
+	Name    string
+	OnEvent func(string)
+}
+
+func TestCopyValues(t *testing.T) {
+	t.Run("Should copy slices, maps and pointers", func(t *testing.T) {
+		ids := []string{"u1", "u2"}
+		filters := map[string]any{"active": true, "tags": []string{"a"}}
+		o := &order{ID: "o1", Items: []string{"i1"}}
+
+		c := copyValues([]any{ids, filters, o, 42, nil})
+
+		ids[0] = "changed"
+		filters["tags"].([]string)[0] = "changed"
+		filters["active"] = false
+		o.Items[0] = "changed"
+		o.ID = "changed"
+
+		assert.Equal(t, []any{
+			[]string{"u1", "u2"},
+			map[string]any{"active": true, "tags": []string{"a"}},
+			&order{ID: "o1", Items: []string{"i1"}},
+			42,
+			nil,
+		}, c)
+	})
+	t.Run("Should copy unexported fields", func(t *testing.T) {
+		o := order{ID: "o1", notes: map[string]string{"a": "b"}}
+
+		c := copyValues([]any{o})
+		o.notes["a"] = "changed"
+
+		assert.Equal(t, "b", c[0].(order).notes["a"])
+	})
+	t.Run("Should keep cycles and shared pointers", func(t *testing.T) {
+		o := &order{ID: "o1"}
+		o.next = o
+
+		c := copyValues([]any{o, o})
+
+		copied := c[0].(*order)
+		assert.NotSame(t, o, copied)
+		assert.Same(t, copied, copied.next)
+		assert.Same(t, copied, c[1])
+	})
+	t.Run("Should not copy contexts and skipped types", func(t *testing.T) {
+		SkipArgCopy[handle]()
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		h := &handle{Name: "h1"}
+		now := time.Now()
+
+		c := copyValues([]any{ctx, h, now})
+
+		assert.Equal(t, ctx, c[0])
+		assert.Same(t, h, c[1])
+		assert.True(t, now.Equal(c[2].(time.Time)))
+		assert.Same(t, now.Location(), c[2].(time.Time).Location())
+	})
+	t.Run("Should keep the nil values", func(t *testing.T) {
+		assert.Nil(t, copyValues(nil))
+		assert.Equal(t, []any{}, copyValues([]any{}))
+		assert.Equal(t, []any{[]int(nil), (*order)(nil)}, copyValues([]any{[]int(nil), (*order)(nil)}))
+	})
+}
+
+func TestRegisterMethodCallCopy(t *testing.T) {
+	t.Run("Should register the args as they were when the method was called", func(t *testing.T) {
+		m := NewMock()
+		ids := []string{"u1", "u2"}
+
+		m.RegisterMethodCall("Delete", ids)
+		ids[0] = "u3"
+		m.RegisterMethodCall("Delete", ids)
+
+		assert.True(t, m.Method("Delete").CalledWith([]string{"u1", "u2"}))
+		assert.True(t, m.Method("Delete").CalledWith([]string{"u3", "u2"}))
+	})
+	t.Run("Should match the args with functions after the copy", func(t *testing.T) {
+		m := NewMock()
+		cfg := &svcCfg{Name: "svc", OnEvent: func(string) {}}
+		client := &http.Client{CheckRedirect: func(*http.Request, []*http.Request) error { return nil }}
+
+		m.RegisterMethodCall("Start", cfg, client, t)
+
+		assert.True(t, m.CalledWith(cfg))
+		assert.True(t, m.CalledWith(client))
+		assert.True(t, m.CalledWith(t))
+		assert.False(t, m.CalledWith(&svcCfg{Name: "svc", OnEvent: func(string) { panic("other") }}))
+	})
+}
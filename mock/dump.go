@@ -0,0 +1,174 @@
+package mock
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+	"text/tabwriter"
+)
+
+// DumpFormat represents a format used to dump the mock calls
+type DumpFormat int
+
+const (
+	// DumpTable dumps the mock calls as a human-readable table
+	DumpTable DumpFormat = iota
+	// DumpJSONLines dumps the mock calls as JSON lines, one call per line
+	DumpJSONLines
+)
+
+// dumpedCall represents a mock call dumped as a JSON line
+type dumpedCall struct {
+	Mock   string            `json:"mock,omitempty"`
+	Index  int               `json:"index"`
+	Method string            `json:"method"`
+	Args   []json.RawMessage `json:"args"`
+}
+
+// DumpCalls writes every mock call to the writer, in the specified format.
+//
+// When the mock has a name, the table starts with it, and every JSON line has it on the "mock" field
+func (mock *Mock) DumpCalls(w io.Writer, format DumpFormat) error {
+	switch format {
+	case DumpTable:
+		return dumpCallsTable(w, mock.state().name, mock.GetCalls())
+	case DumpJSONLines:
+		return dumpCallsJSONLines(w, mock.state().name, mock.GetCalls())
+	default:
+		return fmt.Errorf("unknown dump format %d", format)
+	}
+}
+
+// DumpOnFailure makes the mock log every call as a table when the test fails
+func (mock *Mock) DumpOnFailure(t *testing.T) {
+	t.Cleanup(func() {
+		if !t.Failed() {
+			return
+		}
+
+		t.Log(mock.failureDump())
+	})
+}
+
+// failureDump returns the message logged by DumpOnFailure when the test fails
+func (mock *Mock) failureDump() string {
+	var b strings.Builder
+	if err := mock.DumpCalls(&b, DumpTable); err != nil {
+		return fmt.Sprintf("Failed to dump the mock calls: %s", err)
+	}
+
+	return fmt.Sprintf("Calls of the %s:\n%s", mock.describe(), b.String())
+}
+
+func dumpCallsTable(w io.Writer, name string, calls []MockCall) error {
+	if name != "" {
+		if _, err := fmt.Fprintf(w, "%s:\n", name); err != nil {
+			return err
+		}
+	}
+
+	if len(calls) == 0 {
+		_, err := fmt.Fprintln(w, "(no calls)")
+		return err
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "#\tMETHOD\tARGS")
+	for i, call := range calls {
+		args := make([]string, len(call.Args))
+		for j, arg := range call.Args {
+			args[j] = renderValue(arg)
+		}
+
+		fmt.Fprintf(tw, "%d\t%s\t%s\n", i+1, call.MethodName, strings.Join(args, ", "))
+	}
+
+	return tw.Flush()
+}
+
+// encodeDumpedArg encodes a call argument as JSON.
+//
+// The values that can't be encoded, like functions and channels, and the types that would lose their content,
+// like errors and structs with only unexported fields, are dumped by their rendered form
+func encodeDumpedArg(arg any) json.RawMessage {
+	if !dumpsRendered(reflect.TypeOf(arg)) {
+		if raw, err := marshalDumpedValue(arg); err == nil {
+			return raw
+		}
+	}
+
+	raw, _ := marshalDumpedValue(renderValue(arg))
+	return raw
+}
+
+// marshalDumpedValue encodes a value as JSON, without escaping the HTML characters, like the & of the rendered pointers
+func marshalDumpedValue(val any) (json.RawMessage, error) {
+	var b bytes.Buffer
+	encoder := json.NewEncoder(&b)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(val); err != nil {
+		return nil, err
+	}
+
+	return bytes.TrimSuffix(b.Bytes(), []byte("\n")), nil
+}
+
+var (
+	errorType         = reflect.TypeOf((*error)(nil)).Elem()
+	jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+)
+
+// dumpsRendered returns if the values of the type are dumped by their rendered form on the JSON lines,
+// since they would lose their content when encoded as JSON
+func dumpsRendered(t reflect.Type) bool {
+	if t == nil || t.Implements(errorType) {
+		return t != nil
+	}
+	if t.Implements(jsonMarshalerType) || t.Implements(textMarshalerType) {
+		return false
+	}
+
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct || t.NumField() == 0 {
+		return false
+	}
+
+	for i := 0; i < t.NumField(); i++ {
+		if t.Field(i).IsExported() {
+			return false
+		}
+	}
+
+	return true
+}
+
+func dumpCallsJSONLines(w io.Writer, name string, calls []MockCall) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetEscapeHTML(false)
+	for i, call := range calls {
+		args := make([]json.RawMessage, len(call.Args))
+		for j, arg := range call.Args {
+			args[j] = encodeDumpedArg(arg)
+		}
+
+		err := encoder.Encode(dumpedCall{
+			Mock:   name,
+			Index:  i + 1,
+			Method: call.MethodName,
+			Args:   args,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
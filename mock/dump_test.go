@@ -0,0 +1,104 @@
+package mock
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDumpCalls(t *testing.T) {
+	t.Run("Should dump the calls as a table", func(t *testing.T) {
+		m := NewMock()
+		m.RegisterMethodCall("GetUser", "u1")
+		m.RegisterMethodCall("Save", &renderUser{Name: "John"}, 42)
+		m.RegisterMethodCall("List")
+
+		var b strings.Builder
+		err := m.DumpCalls(&b, DumpTable)
+
+		assert.Nil(t, err)
+		expected := "#  METHOD   ARGS\n" +
+			"1  GetUser  \"u1\"\n" +
+			"2  Save     &mock.renderUser{Name: \"John\", age: 0, Friends: []*mock.renderUser(nil)}, 42\n" +
+			"3  List     \n"
+		assert.Equal(t, expected, b.String())
+	})
+	t.Run("Should dump an empty table if the mock was not called", func(t *testing.T) {
+		m := NewMock()
+
+		var b strings.Builder
+		err := m.DumpCalls(&b, DumpTable)
+
+		assert.Nil(t, err)
+		assert.Equal(t, "(no calls)\n", b.String())
+	})
+	t.Run("Should dump the calls as JSON lines", func(t *testing.T) {
+		m := NewMock()
+		m.RegisterMethodCall("GetUser", "u1")
+		m.RegisterMethodCall("Save", map[string]int{"a": 1}, make(chan int))
+
+		var b strings.Builder
+		err := m.DumpCalls(&b, DumpJSONLines)
+
+		assert.Nil(t, err)
+		expected := `{"index":1,"method":"GetUser","args":["u1"]}` + "\n" +
+			`{"index":2,"method":"Save","args":[{"a":1},"chan int"]}` + "\n"
+		assert.Equal(t, expected, b.String())
+	})
+	t.Run("Should dump the errors and the structs without exported fields by their rendered form", func(t *testing.T) {
+		type credentials struct {
+			token string
+		}
+		type filter struct {
+			Name string `json:"name,omitempty"`
+		}
+		m := NewMock()
+		m.RegisterMethodCall("Fail", errors.New("boom"), &credentials{token: "t1"}, map[string]int{}, filter{}, time.Time{})
+
+		var b strings.Builder
+		err := m.DumpCalls(&b, DumpJSONLines)
+
+		assert.Nil(t, err)
+		expected := `{"index":1,"method":"Fail","args":["*errors.errorString(\"boom\")","&mock.credentials{token: \"t1\"}",{},{},"0001-01-01T00:00:00Z"]}` + "\n"
+		assert.Equal(t, expected, b.String())
+	})
+	t.Run("Should dump the mock name", func(t *testing.T) {
+		m := NewNamedMock("userRepo")
+		m.RegisterMethodCall("GetUser", "u1")
+
+		var table strings.Builder
+		err := m.DumpCalls(&table, DumpTable)
+		assert.Nil(t, err)
+		assert.Equal(t, "userRepo:\n#  METHOD   ARGS\n1  GetUser  \"u1\"\n", table.String())
+
+		var lines strings.Builder
+		err = m.DumpCalls(&lines, DumpJSONLines)
+		assert.Nil(t, err)
+		assert.Equal(t, `{"mock":"userRepo","index":1,"method":"GetUser","args":["u1"]}`+"\n", lines.String())
+	})
+	t.Run("Should return an error if the format is unknown", func(t *testing.T) {
+		m := NewMock()
+
+		err := m.DumpCalls(&strings.Builder{}, DumpFormat(42))
+
+		assert.EqualError(t, err, "unknown dump format 42")
+	})
+}
+
+func TestDumpOnFailure(t *testing.T) {
+	t.Run("Should not break when the test passes", func(t *testing.T) {
+		m := NewMock()
+		m.DumpOnFailure(t)
+
+		m.RegisterMethodCall("GetUser", "u1")
+	})
+	t.Run("Should log the calls as a table when the test fails", func(t *testing.T) {
+		m := NewNamedMock("userRepo")
+		m.RegisterMethodCall("GetUser", "u1")
+
+		assert.Equal(t, "Calls of the mock userRepo:\nuserRepo:\n#  METHOD   ARGS\n1  GetUser  \"u1\"\n", m.failureDump())
+	})
+}
@@ -0,0 +1,125 @@
+package mock
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// For builds a mock implementation of T at runtime, returning it alongside the mock that backs it.
+//
+// Go cannot create types that implement interfaces at runtime, so T must be a function set:
+// a struct where each exported function-typed field represents a method.
+// Every field is filled with a function that registers the call and returns the response
+// configured for the method with the same name as the field
+// (or the name specified on the field `mock` tag), so you can simply do:
+//
+//	type userRepo struct {
+//		GetUserCount func(userID string) (int, error)
+//	}
+//
+//	repo, m := mock.For[userRepo]()
+//	m.Method("GetUserCount").SetResponse(5, nil)
+//
+//	c, err := repo.GetUserCount("userID") // returns 5, nil
+//
+// This function panics if T is not a struct.
+func For[T any]() (T, *Mock) {
+	m := NewMock()
+
+	var impl T
+	v := reflect.ValueOf(&impl).Elem()
+	t := v.Type()
+
+	if t.Kind() == reflect.Interface {
+		msg := fmt.Sprintf("Tried to build a runtime mock for the interface %s, but interfaces cannot be implemented at runtime. Use a struct with function fields instead", t)
+		panic(msg)
+	}
+	if t.Kind() != reflect.Struct {
+		msg := fmt.Sprintf("Tried to build a runtime mock for the type %s, but only structs with function fields are supported", t)
+		panic(msg)
+	}
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() || field.Type.Kind() != reflect.Func {
+			continue
+		}
+
+		name := field.Name
+		if tag, ok := field.Tag.Lookup("mock"); ok && tag != "" {
+			name = tag
+		}
+
+		v.Field(i).Set(makeMockFunc(&m, name, field.Type))
+	}
+
+	return impl, &m
+}
+
+// Func creates a function of the type F that is backed by the specified mock.
+//
+// Every call to the function is registered on the mock under the specified method name,
+// and returns the response configured through m.Method(name), so function dependencies
+// can be mocked and asserted just like interfaces:
+//
+//	m := mock.NewMock()
+//	getUserCount := mock.Func[func(string) (int, error)](&m, "GetUserCount")
+//	m.Method("GetUserCount").SetResponse(5, nil)
+//
+//	c, err := getUserCount("userID") // returns 5, nil
+//
+// This function panics if F is not a function type.
+func Func[F any](m *Mock, name string) F {
+	var fn F
+	v := reflect.ValueOf(&fn).Elem()
+
+	if v.Kind() != reflect.Func {
+		msg := fmt.Sprintf("Tried to build a mock function of the type %s, but the type is not a function", v.Type())
+		panic(msg)
+	}
+
+	v.Set(makeMockFunc(m, name, v.Type()))
+
+	return fn
+}
+
+// makeMockFunc creates a function of the type fnType that registers its calls on the mock
+// under the specified method name, and returns the response configured for that method.
+//
+// The calls of variadic functions are registered like RegisterVariadicCall does, with the variadic arguments spread
+func makeMockFunc(m *Mock, name string, fnType reflect.Type) reflect.Value {
+	return reflect.MakeFunc(fnType, func(in []reflect.Value) []reflect.Value {
+		args := make([]any, len(in))
+		for i, arg := range in {
+			args[i] = arg.Interface()
+		}
+
+		var res MethodResponse
+		if fnType.IsVariadic() {
+			res = m.GetVariadicResponseAndRegister(name, args[:len(args)-1], args[len(args)-1])
+		} else {
+			res = m.GetResponseAndRegister(name, args...)
+		}
+
+		out := make([]reflect.Value, fnType.NumOut())
+		for i := range out {
+			out[i] = responseValue(name, res, i, fnType.Out(i))
+		}
+
+		return out
+	})
+}
+
+// responseValue converts the response value on the 'i' index to the type t.
+//
+// The zero value of the type is returned if the response has no value on the specified index,
+// and this function panics if the response value is not assignable to the type
+func responseValue(name string, res MethodResponse, i int, t reflect.Type) reflect.Value {
+	val, ok := assignableValue(res.Get(i), t)
+	if !ok {
+		msg := fmt.Sprintf("Tried to return a %s value on the index %d of the mock method %s response, but the index value was a %T", t, i, name, res.Get(i))
+		panic(msg)
+	}
+
+	return val
+}
@@ -0,0 +1,121 @@
+package mock
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type userRepoFuncs struct {
+	GetUserCount func(userID string) (int, error)
+	DeleteUser   func(userID string) error `mock:"Delete"`
+	unexported   func()
+}
+
+func TestFor(t *testing.T) {
+	t.Run("Should route every function field to the mock", func(t *testing.T) {
+		repo, m := For[userRepoFuncs]()
+		m.Method("GetUserCount").SetResponse(5, nil)
+
+		c, err := repo.GetUserCount("userID")
+
+		assert.Equal(t, 5, c)
+		assert.Nil(t, err)
+		assert.True(t, m.Method("GetUserCount").CalledWith("userID"))
+		assert.Nil(t, repo.unexported)
+	})
+	t.Run("Should return the zero values if no response was specified", func(t *testing.T) {
+		repo, m := For[userRepoFuncs]()
+
+		c, err := repo.GetUserCount("userID")
+
+		assert.Equal(t, 0, c)
+		assert.Nil(t, err)
+		assert.True(t, m.CalledOnce())
+	})
+	t.Run("Should use the mock tag as the method name", func(t *testing.T) {
+		repo, m := For[userRepoFuncs]()
+		mockErr := errors.New("mock error")
+		m.Method("Delete").SetResponse(mockErr)
+
+		err := repo.DeleteUser("userID")
+
+		assert.Equal(t, mockErr, err)
+		assert.True(t, m.Method("Delete").CalledOnce())
+	})
+	t.Run("Should panic if the response value has the wrong type", func(t *testing.T) {
+		repo, m := For[userRepoFuncs]()
+		m.Method("GetUserCount").SetResponse("5", nil)
+
+		assert.PanicsWithValue(t,
+			"Tried to return a int value on the index 0 of the mock method GetUserCount response, but the index value was a string",
+			func() {
+				_, _ = repo.GetUserCount("userID")
+			},
+		)
+	})
+	t.Run("Should panic if the type is an interface", func(t *testing.T) {
+		assert.Panics(t, func() {
+			For[error]()
+		})
+	})
+	t.Run("Should panic if the type is not a struct", func(t *testing.T) {
+		assert.Panics(t, func() {
+			For[int]()
+		})
+	})
+}
+
+func TestFunc(t *testing.T) {
+	t.Run("Should create a function that registers calls and returns the method response", func(t *testing.T) {
+		m := NewMock()
+		fn := Func[func(string) (int, error)](&m, "GetUserCount")
+		m.Method("GetUserCount").SetResponse(5, nil)
+
+		c, err := fn("userID")
+
+		assert.Equal(t, 5, c)
+		assert.Nil(t, err)
+		assert.True(t, m.Method("GetUserCount").CalledOnce())
+		assert.True(t, m.Method("GetUserCount").CalledWithExactly("userID"))
+	})
+	t.Run("Should consider the responses specified with args", func(t *testing.T) {
+		m := NewMock()
+		fn := Func[func(string, int) string](&m, "Format")
+		m.Method("Format").WithArgs("a", 1).Returns("specific")
+		m.Method("Format").SetResponse("default")
+
+		assert.Equal(t, "specific", fn("a", 1))
+		assert.Equal(t, "default", fn("b", 2))
+		assert.True(t, m.Method("Format").CalledTimes(2))
+	})
+	t.Run("Should spread the variadic arguments", func(t *testing.T) {
+		m := NewMock()
+		fn := Func[func(string, ...int) int](&m, "Sum")
+		m.Method("Sum").WithArgs("ctx", 1, 2).Returns(7)
+
+		assert.Equal(t, 7, fn("ctx", 1, 2))
+		assert.Equal(t, 0, fn("ctx"))
+		assert.True(t, m.Method("Sum").CalledWithExactly("ctx", 1, 2))
+		assert.True(t, m.Method("Sum").CalledWithExactly("ctx"))
+	})
+	t.Run("Should support functions without return values", func(t *testing.T) {
+		m := NewMock()
+		fn := Func[func(int)](&m, "Notify")
+
+		fn(42)
+
+		assert.True(t, m.Method("Notify").CalledWith(42))
+	})
+	t.Run("Should panic if the type is not a function", func(t *testing.T) {
+		m := NewMock()
+
+		assert.PanicsWithValue(t,
+			"Tried to build a mock function of the type string, but the type is not a function",
+			func() {
+				Func[string](&m, "NotAFunc")
+			},
+		)
+	})
+}
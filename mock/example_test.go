@@ -0,0 +1,138 @@
+package mock_test
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/delivery-much/mock-helper/mock"
+)
+
+type MyDBInterface interface {
+	GetUserCount(userID string) (int, error)
+}
+
+type dbMock struct {
+	mock.Mock
+}
+
+func NewDBMock() dbMock {
+	return dbMock{
+		mock.NewMock(),
+	}
+}
+
+func (m *dbMock) GetUserCount(userID string) (c int, err error) {
+	m.RegisterMethodCall("GetUserCount", userID)
+
+	res := m.GetMethodResponse("GetUserCount", userID)
+	if res.IsEmpty() {
+		return
+	}
+
+	return res.GetInt(0), res.GetError(1)
+}
+
+func GetCount(db MyDBInterface, userID string) (int, error) {
+	c, err := db.GetUserCount(userID)
+	if err != nil {
+		return 0, err
+	}
+
+	return c, err
+}
+
+func Example() {
+	db := NewDBMock()
+	db.Method("GetUserCount").SetResponse(5, nil)
+
+	c, err := GetCount(&db, "mockUserID")
+	fmt.Println(c, err)
+	fmt.Println(db.Method("GetUserCount").CalledOnce(), db.Method("GetUserCount").CalledWith("mockUserID"))
+
+	db.Reset()
+	db.Method("GetUserCount").SetResponse(5, errors.New("Mock error"))
+
+	c, err = GetCount(&db, "mockUserID")
+	fmt.Println(c, err)
+	// Output:
+	// 5 <nil>
+	// true true
+	// 0 Mock error
+}
+
+// stubUser is a helper that specifies the response of a GetUser method for a user
+func stubUser(m *mock.Method, userID, name string) {
+	m.WithArgs(userID).Returns(name, nil)
+}
+
+func Example_helpers() {
+	m := mock.NewMock()
+	stubUser(m.Method("GetUser"), "u1", "John")
+	stubUser(m.Method("GetUser"), "u2", "Jane")
+
+	var res mock.MethodResponse = m.GetResponseAndRegister("GetUser", "u2")
+	fmt.Println(res.GetString(0))
+	// Output: Jane
+}
+
+func ExampleMock_SetMethodResponse() {
+	m := mock.NewMock()
+	m.SetMethodResponse("MyMethod", "response", 42)
+
+	res := m.GetMethodResponse("MyMethod")
+	fmt.Println(res.GetString(0), res.GetInt(1))
+	// Output: response 42
+}
+
+func ExampleMock_CalledWith() {
+	m := mock.NewMock()
+	m.RegisterMethodCall("MyMethod", "param1", 42)
+
+	fmt.Println(m.CalledWith(42))
+	fmt.Println(m.CalledWithExactly(42, "param1"))
+	// Output:
+	// true
+	// false
+}
+
+func ExampleMethod_SetResponse() {
+	m := mock.NewMock()
+
+	myMethod := m.Method("MyMethod")
+	myMethod.SetResponse("mock response")
+
+	fmt.Println(myMethod.GetResponse().GetString(0))
+	// Output: mock response
+}
+
+func ExampleWithArgs_Returns() {
+	m := mock.NewMock()
+	myMethod := m.Method("MyMethod")
+
+	myMethod.WithArgs("param1", 42).Returns("my specified return!!")
+	myMethod.WithArgs(mock.MatchAny{}, 12).Returns("any first param, with 12")
+	myMethod.SetResponse("a default response")
+
+	fmt.Println(m.GetResponseAndRegister("MyMethod", "param1", 42).GetString(0))
+	fmt.Println(m.GetResponseAndRegister("MyMethod", "some other param", 12).GetString(0))
+	fmt.Println(m.GetResponseAndRegister("MyMethod", "some other param", 13).GetString(0))
+	// Output:
+	// my specified return!!
+	// any first param, with 12
+	// a default response
+}
+
+func ExampleMethodResponse_IsEmpty() {
+	fmt.Println(mock.MethodResponse{}.IsEmpty())
+	fmt.Println(mock.MethodResponse{42, "PARAM"}.IsEmpty())
+	// Output:
+	// true
+	// false
+}
+
+func ExampleMethodResponse_Get() {
+	response := mock.MethodResponse{"value1", 42}
+
+	fmt.Println(response.Get(0), response.Get(1))
+	// Output: value1 42
+}
@@ -0,0 +1,146 @@
+package mock
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/pmezard/go-difflib/difflib"
+)
+
+// updateFlag is the name of the flag that makes MatchSnapshot rewrite the golden files,
+// instead of comparing the mock calls with them.
+//
+// It's namespaced, so it doesn't clash with the -update flag that test packages usually define for their own golden files
+const updateFlag = "mock.update"
+
+// updateEnv is the environment variable that also makes MatchSnapshot rewrite the golden files
+const updateEnv = "MOCK_UPDATE"
+
+func init() {
+	if flag.Lookup(updateFlag) == nil {
+		flag.Bool(updateFlag, false, "rewrite the mock interaction golden files")
+	}
+}
+
+// updateSnapshots returns if the golden files should be rewritten
+func updateSnapshots() bool {
+	if update, err := strconv.ParseBool(os.Getenv(updateEnv)); err == nil && update {
+		return true
+	}
+
+	f := flag.Lookup(updateFlag)
+	if f == nil {
+		return false
+	}
+
+	update, _ := strconv.ParseBool(f.Value.String())
+	return update
+}
+
+var invalidFileNameChars = regexp.MustCompile(`[^a-zA-Z0-9_\-.]+`)
+
+// MatchSnapshot asserts that the calls of the specified mocks match the ones stored on the test golden file,
+// located at testdata/<test name>.golden.
+//
+// Every mock call is serialized with its method name and arguments, rendered in a deterministic way,
+// and grouped by the mock name (or its position, for mocks without a name).
+// When the test runs with the -mock.update flag (or with the MOCK_UPDATE=true environment variable), the golden file is rewritten with the current calls.
+// When the golden file does not exist, it's created with them too, but the test fails, so a missing golden file doesn't go unnoticed on CI
+func MatchSnapshot(t *testing.T, mocks ...*Mock) {
+	t.Helper()
+
+	path := filepath.Join("testdata", invalidFileNameChars.ReplaceAllString(t.Name(), "_")+".golden")
+	if msg := matchSnapshotFile(path, renderSnapshot(mocks...)); msg != "" {
+		t.Error(msg)
+	}
+}
+
+// matchSnapshotFile compares the actual snapshot with the golden file on the path, writing it when needed,
+// and returns the failure message, or an empty string if the snapshot matches
+func matchSnapshotFile(path, actual string) string {
+	update := updateSnapshots()
+
+	expected, err := os.ReadFile(path)
+	missing := errors.Is(err, fs.ErrNotExist)
+	if missing || update {
+		if err := writeSnapshot(path, actual); err != nil {
+			return fmt.Sprintf("Failed to write the mock interaction golden file %s: %s", path, err)
+		}
+		if missing && !update {
+			return fmt.Sprintf("The mock interaction golden file %s did not exist, so it was created with the current calls (check and commit it, or run the test with the -mock.update flag to create it)", path)
+		}
+
+		return ""
+	}
+	if err != nil {
+		return fmt.Sprintf("Failed to read the mock interaction golden file %s: %s", path, err)
+	}
+
+	if diff := mountSnapshotDiff(string(expected), actual); diff != "" {
+		return fmt.Sprintf("Failed to match the mock interactions with the golden file %s (run the test with the -mock.update flag to rewrite it):\n%s", path, diff)
+	}
+
+	return ""
+}
+
+// renderSnapshot serializes the calls of the specified mocks
+func renderSnapshot(mocks ...*Mock) (snapshot string) {
+	for i, m := range mocks {
+		if m.state().name == "" {
+			snapshot = fmt.Sprintf("%smock %d:\n", snapshot, i+1)
+		} else {
+			snapshot = fmt.Sprintf("%s%s:\n", snapshot, m.describe())
+		}
+
+		calls := m.GetCalls()
+		if len(calls) == 0 {
+			snapshot = fmt.Sprintf("%s  (no calls)\n", snapshot)
+			continue
+		}
+
+		for j, call := range calls {
+			args := make([]string, len(call.Args))
+			for k, arg := range call.Args {
+				args[k] = renderValue(arg)
+			}
+
+			snapshot = fmt.Sprintf("%s  [%d] %s(%s)\n", snapshot, j+1, call.MethodName, strings.Join(args, ", "))
+		}
+	}
+
+	return
+}
+
+// mountSnapshotDiff returns a readable diff between the expected and the actual snapshot,
+// or an empty string if they are equal
+func mountSnapshotDiff(expected, actual string) string {
+	if expected == actual {
+		return ""
+	}
+
+	diff, _ := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(expected),
+		B:        difflib.SplitLines(actual),
+		FromFile: "Expected",
+		ToFile:   "Actual",
+		Context:  2,
+	})
+
+	return diff
+}
+
+func writeSnapshot(path, snapshot string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, []byte(snapshot), 0o644)
+}
@@ -0,0 +1,24 @@
+package mock_test
+
+import (
+	"flag"
+	"testing"
+
+	"github.com/delivery-much/mock-helper/mock"
+	"github.com/stretchr/testify/assert"
+)
+
+// update is the flag a consumer test package usually defines for its own golden files,
+// which must not clash with the flag registered by the mock package
+var update = flag.Bool("update", false, "rewrite the golden files")
+
+func TestUpdateFlag(t *testing.T) {
+	t.Run("Should not clash with an update flag defined by the test package", func(t *testing.T) {
+		assert.NotNil(t, flag.Lookup("update"))
+		assert.False(t, *update)
+		assert.NotNil(t, flag.Lookup("mock.update"))
+
+		m := mock.NewMock()
+		mock.MatchSnapshot(t, &m)
+	})
+}
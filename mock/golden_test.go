@@ -0,0 +1,103 @@
+package mock
+
+import (
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatchSnapshot(t *testing.T) {
+	t.Run("Should match the calls stored on the golden file", func(t *testing.T) {
+		userRepo := NewMock()
+		notifier := NewMock()
+
+		userRepo.RegisterMethodCall("GetUser", "u1")
+		userRepo.RegisterMethodCall("Save", &renderUser{Name: "John", age: 30}, map[string]int{"b": 2, "a": 1})
+
+		MatchSnapshot(t, &userRepo, &notifier)
+	})
+}
+
+func TestMatchSnapshotFile(t *testing.T) {
+	t.Run("Should create the missing golden file and fail", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "testdata", "missing.golden")
+
+		msg := matchSnapshotFile(path, "mock 1:\n  (no calls)\n")
+
+		assert.Equal(t, fmt.Sprintf("The mock interaction golden file %s did not exist, so it was created with the current calls (check and commit it, or run the test with the -mock.update flag to create it)", path), msg)
+		assert.Empty(t, matchSnapshotFile(path, "mock 1:\n  (no calls)\n"))
+	})
+	t.Run("Should create the missing golden file without failing when updating", func(t *testing.T) {
+		t.Setenv("MOCK_UPDATE", "true")
+		path := filepath.Join(t.TempDir(), "testdata", "missing.golden")
+
+		assert.Empty(t, matchSnapshotFile(path, "mock 1:\n  (no calls)\n"))
+		assert.FileExists(t, path)
+	})
+	t.Run("Should fail if the snapshot does not match the golden file", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "testdata", "changed.golden")
+		_ = matchSnapshotFile(path, "mock 1:\n  (no calls)\n")
+
+		assert.Contains(t, matchSnapshotFile(path, "mock 1:\n  [1] GetUser(\"u1\")\n"), "Failed to match the mock interactions with the golden file")
+	})
+}
+
+func TestUpdateSnapshots(t *testing.T) {
+	t.Run("Should not update the golden files by default", func(t *testing.T) {
+		assert.False(t, updateSnapshots())
+	})
+	t.Run("Should update the golden files with the environment variable", func(t *testing.T) {
+		t.Setenv("MOCK_UPDATE", "true")
+
+		assert.True(t, updateSnapshots())
+	})
+}
+
+func TestRenderSnapshot(t *testing.T) {
+	t.Run("Should render the calls of every mock", func(t *testing.T) {
+		m1 := NewMock()
+		m2 := NewMock()
+
+		m1.RegisterMethodCall("GetUser", "u1", 42)
+		m1.RegisterMethodCall("List")
+
+		expected := "mock 1:\n" +
+			"  [1] GetUser(\"u1\", 42)\n" +
+			"  [2] List()\n" +
+			"mock 2:\n" +
+			"  (no calls)\n"
+		assert.Equal(t, expected, renderSnapshot(&m1, &m2))
+	})
+}
+
+func TestRenderNamedSnapshot(t *testing.T) {
+	t.Run("Should use the mock names", func(t *testing.T) {
+		m1 := NewNamedMock("userRepo")
+		m2 := NewMock()
+
+		m1.RegisterMethodCall("GetUser", "u1")
+
+		expected := "mock userRepo:\n" +
+			"  [1] GetUser(\"u1\")\n" +
+			"mock 2:\n" +
+			"  (no calls)\n"
+		assert.Equal(t, expected, renderSnapshot(&m1, &m2))
+	})
+}
+
+func TestMountSnapshotDiff(t *testing.T) {
+	t.Run("Should return empty if the snapshots are equal", func(t *testing.T) {
+		assert.Empty(t, mountSnapshotDiff("mock 1:\n", "mock 1:\n"))
+	})
+	t.Run("Should return a readable diff if the snapshots are different", func(t *testing.T) {
+		expected := "mock 1:\n  [1] GetUser(\"u1\")\n"
+		actual := "mock 1:\n  [1] GetUser(\"u2\")\n"
+
+		diff := mountSnapshotDiff(expected, actual)
+
+		assert.Contains(t, diff, "-  [1] GetUser(\"u1\")\n")
+		assert.Contains(t, diff, "+  [1] GetUser(\"u2\")\n")
+	})
+}
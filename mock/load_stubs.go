@@ -0,0 +1,245 @@
+package mock
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"regexp"
+
+	"gopkg.in/yaml.v3"
+)
+
+// stubsDocument represents a YAML or JSON document with the stubs of a mock
+type stubsDocument struct {
+	Stubs []stubEntry `yaml:"stubs"`
+}
+
+// stubEntry represents a stub declared on a stubs document
+type stubEntry struct {
+	Method string `yaml:"method"`
+	// Args is nil when the stub is the method default response
+	Args    *[]any `yaml:"args"`
+	Returns []any  `yaml:"returns"`
+}
+
+// LoadStubs loads the stubs declared on a YAML or JSON document, so mock scenarios can be defined without any Go code.
+//
+// The document must have a list of stubs, each one with the method name, the arguments and the response values.
+// Each argument can be a plain value, matched by equality, or a matcher: {equal: value}, {any: true}, {type: name} or {regex: pattern}.
+// Each response value can be a plain value, {value: value}, or {error: message}.
+// A stub without args is the method default response:
+//
+//	stubs:
+//	  - method: GetUserCount
+//	    args: ["user1"]
+//	    returns: [5, null]
+//	  - method: GetUserCount
+//	    args: [{regex: "^admin"}]
+//	    returns: [0, {error: "forbidden"}]
+//	  - method: GetUserCount
+//	    returns: [1, null]
+//
+// When the mock has declared signatures, the values are converted to the method argument and response types.
+// An error naming the offending entry is returned if the document is invalid
+func (mock *Mock) LoadStubs(path string) error {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	decoder := yaml.NewDecoder(bytes.NewReader(content))
+	decoder.KnownFields(true)
+
+	var doc stubsDocument
+	if err := decoder.Decode(&doc); err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("failed to decode the stubs document %s: %w", path, err)
+	}
+
+	// every stub is validated before any of them is specified on the mock
+	stubs := make([]Stub, len(doc.Stubs))
+	for i, entry := range doc.Stubs {
+		s, err := mock.decodeStubEntry(entry)
+		if err != nil {
+			return fmt.Errorf("invalid stub %d (%s) on %s: %w", i, entry.Method, path, err)
+		}
+		stubs[i] = s
+	}
+
+	for _, s := range stubs {
+		if s.Args == nil {
+			mock.Method(s.MethodName).SetResponse(s.Response...)
+			continue
+		}
+
+		mock.Method(s.MethodName).WithArgs(s.Args...).Returns(s.Response...)
+	}
+
+	return nil
+}
+
+func (mock *Mock) decodeStubEntry(entry stubEntry) (s Stub, err error) {
+	if entry.Method == "" {
+		return s, errors.New("the method name is required")
+	}
+
+	signature, hasSignature := mock.state().signatures[entry.Method]
+	if mock.state().boundTo != nil && !hasSignature {
+		return s, fmt.Errorf("the method %s does not belong to %s", entry.Method, mock.state().boundTo)
+	}
+
+	s.MethodName = entry.Method
+
+	if entry.Args != nil {
+		s.Args = []any{}
+		for i, arg := range *entry.Args {
+			var argType reflect.Type
+			if hasSignature && (i < signature.NumIn() || signature.IsVariadic()) {
+				argType = argumentType(signature, i)
+			}
+
+			decoded, err := decodeStubArg(arg, argType)
+			if err != nil {
+				return s, fmt.Errorf("argument %d: %w", i, err)
+			}
+			s.Args = append(s.Args, decoded)
+		}
+
+		if hasSignature {
+			if problem := checkArgs(signature, s.Args); problem != "" {
+				return s, errors.New(problem)
+			}
+		}
+	}
+
+	for i, val := range entry.Returns {
+		var outType reflect.Type
+		if hasSignature && i < signature.NumOut() {
+			outType = signature.Out(i)
+		}
+
+		decoded, err := decodeStubResponseValue(val, outType)
+		if err != nil {
+			return s, fmt.Errorf("response value %d: %w", i, err)
+		}
+		s.Response = append(s.Response, decoded)
+	}
+
+	if hasSignature {
+		if problem := checkResponse(signature, s.Response); problem != "" {
+			return s, errors.New(problem)
+		}
+	}
+
+	return s, nil
+}
+
+// decodeStubArg decodes an argument declared on a stubs document,
+// converting it to the type t when it's known
+func decodeStubArg(arg any, t reflect.Type) (any, error) {
+	matcher, ok := arg.(map[string]any)
+	if !ok {
+		return convertStubValue(arg, t)
+	}
+
+	if len(matcher) != 1 {
+		return nil, errors.New("a matcher must have exactly one of the keys equal, any, type or regex")
+	}
+
+	for key, val := range matcher {
+		switch key {
+		case "equal":
+			return convertStubValue(val, t)
+		case "any":
+			if val != true {
+				return nil, errors.New("the any matcher must be true")
+			}
+			return MatchAny{}, nil
+		case "type":
+			name, ok := val.(string)
+			if !ok {
+				return nil, errors.New("the type matcher must be a type name")
+			}
+
+			registeredTypesMu.RLock()
+			matchType, ok := registeredTypes[name]
+			registeredTypesMu.RUnlock()
+			if !ok {
+				return nil, fmt.Errorf("the type %s is not registered, use RegisterType to register it", name)
+			}
+			return matchReflectType{matchType}, nil
+		case "regex":
+			pattern, ok := val.(string)
+			if !ok {
+				return nil, errors.New("the regex matcher must be a string")
+			}
+
+			re, err := regexp.Compile(pattern)
+			if err != nil {
+				return nil, fmt.Errorf("invalid regex matcher: %w", err)
+			}
+			return MatchRegex{Regexp: re}, nil
+		default:
+			return nil, fmt.Errorf("unknown matcher %s, it must be one of equal, any, type or regex", key)
+		}
+	}
+
+	return nil, nil
+}
+
+// decodeStubResponseValue decodes a response value declared on a stubs document,
+// converting it to the type t when it's known
+func decodeStubResponseValue(val any, t reflect.Type) (any, error) {
+	wrapper, ok := val.(map[string]any)
+	if !ok {
+		return convertStubValue(val, t)
+	}
+
+	if len(wrapper) != 1 {
+		return nil, errors.New("a response value object must have exactly one of the keys value or error")
+	}
+
+	if msg, ok := wrapper["error"]; ok {
+		s, ok := msg.(string)
+		if !ok {
+			return nil, errors.New("the error must be a string message")
+		}
+		return errors.New(s), nil
+	}
+
+	if v, ok := wrapper["value"]; ok {
+		return convertStubValue(v, t)
+	}
+
+	return nil, errors.New("a response value object must have exactly one of the keys value or error")
+}
+
+// convertStubValue converts a value decoded from a stubs document to the type t.
+//
+// The value is kept as decoded if the type is unknown
+func convertStubValue(val any, t reflect.Type) (any, error) {
+	if val == nil || t == nil {
+		return val, nil
+	}
+
+	rv := reflect.ValueOf(val)
+	if rv.Type().AssignableTo(t) {
+		return val, nil
+	}
+
+	// the document values are re-encoded to JSON, so they can be decoded to any type, including structs
+	content, err := json.Marshal(val)
+	if err != nil {
+		return nil, err
+	}
+
+	converted := reflect.New(t)
+	if err := json.Unmarshal(content, converted.Interface()); err != nil {
+		return nil, fmt.Errorf("the value %v can't be converted to %s", val, t)
+	}
+
+	return converted.Elem().Interface(), nil
+}
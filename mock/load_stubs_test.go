@@ -0,0 +1,139 @@
+package mock
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func writeStubsDocument(t *testing.T, name, content string) string {
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	return path
+}
+
+func TestLoadStubs(t *testing.T) {
+	t.Run("Should load the stubs from a YAML document", func(t *testing.T) {
+		path := writeStubsDocument(t, "stubs.yaml", `
+stubs:
+  - method: GetUserCount
+    args: ["user1"]
+    returns: [5, null]
+  - method: GetUserCount
+    args: [{regex: "^admin"}]
+    returns: [0, {error: "forbidden"}]
+  - method: GetUserCount
+    returns: [1, null]
+  - method: List
+    args: [{any: true}, {type: int}, {equal: [1, 2]}]
+    returns: [{value: {a: 1}}]
+`)
+		m := NewMock()
+
+		err := m.LoadStubs(path)
+
+		assert.Nil(t, err)
+		assert.Equal(t, MethodResponse{5, nil}, m.GetMethodResponse("GetUserCount", "user1"))
+		assert.Equal(t, MethodResponse{0, errors.New("forbidden")}, m.GetMethodResponse("GetUserCount", "admin2"))
+		assert.Equal(t, MethodResponse{1, nil}, m.GetMethodResponse("GetUserCount", "user2"))
+		assert.Equal(t, MethodResponse{map[string]any{"a": 1}}, m.GetMethodResponse("List", "anything", 42, []any{1, 2}))
+		assert.Empty(t, m.GetMethodResponse("List", "anything", "42", []any{1, 2}))
+	})
+	t.Run("Should load the stubs from a JSON document", func(t *testing.T) {
+		path := writeStubsDocument(t, "stubs.json", `{
+  "stubs": [
+    {"method": "GetUserCount", "args": ["user1"], "returns": [5, null]}
+  ]
+}`)
+		m := NewMock()
+
+		err := m.LoadStubs(path)
+
+		assert.Nil(t, err)
+		assert.Equal(t, MethodResponse{5, nil}, m.GetMethodResponse("GetUserCount", "user1"))
+	})
+	t.Run("Should convert the values to the declared signature types", func(t *testing.T) {
+		path := writeStubsDocument(t, "stubs.yaml", `
+stubs:
+  - method: Find
+    args: [{equal: 10}, {equal: {id: "u1", name: "John"}}]
+    returns: [{value: {id: "u1", name: "John"}}, 3]
+`)
+		type user struct {
+			ID   string `json:"id"`
+			Name string `json:"name"`
+		}
+		m := NewMock()
+		m.Method("Find").SetSignature((func(int64, user) (user, uint8))(nil))
+
+		err := m.LoadStubs(path)
+
+		assert.Nil(t, err)
+		res := m.GetMethodResponse("Find", int64(10), user{"u1", "John"})
+		assert.Equal(t, MethodResponse{user{"u1", "John"}, uint8(3)}, res)
+	})
+	t.Run("Should return an error naming the offending entry", func(t *testing.T) {
+		tests := []struct {
+			content     string
+			expectedErr string
+		}{
+			{
+				content:     "stubs:\n  - returns: [1]\n",
+				expectedErr: "invalid stub 0 () on %s: the method name is required",
+			},
+			{
+				content:     "stubs:\n  - method: DeleteUser\n    returns: [null]\n  - method: DeleteUser\n    args: [{foo: 1}]\n",
+				expectedErr: "invalid stub 1 (DeleteUser) on %s: argument 0: unknown matcher foo, it must be one of equal, any, type or regex",
+			},
+			{
+				content:     "stubs:\n  - method: DeleteUser\n    args: [{regex: \"(\"}]\n",
+				expectedErr: "invalid stub 0 (DeleteUser) on %s: argument 0: invalid regex matcher: error parsing regexp: missing closing ): `(`",
+			},
+			{
+				content:     "stubs:\n  - method: DeleteUser\n    args: [{type: mock.unknownType}]\n",
+				expectedErr: "invalid stub 0 (DeleteUser) on %s: argument 0: the type mock.unknownType is not registered, use RegisterType to register it",
+			},
+			{
+				content:     "stubs:\n  - method: DeleteUser\n    returns: [{error: \"a\", value: 1}]\n",
+				expectedErr: "invalid stub 0 (DeleteUser) on %s: response value 0: a response value object must have exactly one of the keys value or error",
+			},
+			{
+				content:     "stubs:\n  - method: GetUsers\n    returns: [1]\n",
+				expectedErr: "invalid stub 0 (GetUsers) on %s: the method GetUsers does not belong to mock.userRepo",
+			},
+			{
+				content:     "stubs:\n  - method: GetUserCount\n    returns: [\"five\", null]\n",
+				expectedErr: "invalid stub 0 (GetUserCount) on %s: response value 0: the value five can't be converted to int",
+			},
+			{
+				content:     "stubs:\n  - method: GetUserCount\n    returns: [5]\n",
+				expectedErr: "invalid stub 0 (GetUserCount) on %s: the method returns 2 values, and 1 response values were specified",
+			},
+		}
+
+		for _, test := range tests {
+			path := writeStubsDocument(t, "stubs.yaml", test.content)
+			m := NewMockFor[userRepo]()
+
+			err := m.LoadStubs(path)
+
+			assert.EqualError(t, err, fmt.Sprintf(test.expectedErr, path))
+			assert.Empty(t, m.state().stubs)
+		}
+	})
+	t.Run("Should reject unknown stub fields", func(t *testing.T) {
+		path := writeStubsDocument(t, "stubs.yaml", "stubs:\n  - method: Get\n    response: [1]\n")
+		m := NewMock()
+
+		err := m.LoadStubs(path)
+
+		assert.ErrorContains(t, err, "field response not found")
+	})
+}
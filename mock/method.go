@@ -1,11 +1,46 @@
 package mock
 
-import "testing"
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
 
-// method represents a mock use information, but filtered for a specific method
-type method struct {
+// Method represents a mock use information, but filtered for a specific method
+type Method struct {
 	name string
 	mock *Mock
+	// since is the sequence number of the first mock call considered by the method
+	since int
+	// isolated indicates if the responses specified through the method should be deep copied on every call
+	isolated bool
+}
+
+// fullName returns the method name, prefixed by the mock name when the mock has one
+func (m *Method) fullName() string {
+	if m.mock == nil || m.mock.state().name == "" {
+		return m.name
+	}
+
+	return fmt.Sprintf("%s.%s", m.mock.state().name, m.name)
+}
+
+// equality returns how the method mock compares values
+func (m *Method) equality() *equality {
+	if m.mock == nil {
+		return nil
+	}
+
+	return m.mock.state().equality
+}
+
+// describeArg returns how the argument 'i' of the method should be referred to on messages
+func (m *Method) describeArg(i int) string {
+	if m.mock == nil {
+		return fmt.Sprintf("argument %d", i)
+	}
+
+	return m.mock.describeArg(m.name, i)
 }
 
 // SetResponse sets the response that the mock method should return when called
@@ -13,14 +48,88 @@ type method struct {
 // Its imperative that the response values specified are
 // of the same type and are in the same order as the method
 // response specified in the method signature
-func (m *method) SetResponse(response ...any) {
+func (m *Method) SetResponse(response ...any) {
 	if m.mock != nil {
 		m.mock.SetMethodResponse(m.name, response...)
+		m.mock.state().stubs[m.name].isolated = m.isolated
+	}
+}
+
+// SetLazyResponse sets a function that builds the response that the mock method should return,
+// which is called on every method call, so each caller gets fresh values
+func (m *Method) SetLazyResponse(build func() []any) {
+	if m.mock != nil {
+		m.mock.validateMethod("set a response for", m.name)
+		m.mock.setStub(m.name, m.name, nil, nil).lazy = build
+	}
+}
+
+// Isolated makes the responses specified through the returned method be deep copied on every method call,
+// so a caller that changes the values it received doesn't affect the other callers:
+//
+//	m.Method("GetOrders").Isolated().SetResponse([]Order{{ID: "o1"}}, nil)
+//
+// The types that skip the argument copy (see SkipArgCopy) are not copied either
+func (m *Method) Isolated() *Method {
+	isolated := *m
+	isolated.isolated = true
+
+	return &isolated
+}
+
+// CallThrough sets a real implementation that the mock method should call when no response was specified,
+// turning the method into a spy.
+//
+// The method calls are still registered, and the values returned by the real implementation are used as the method response.
+//
+// This method panics if realFn is not a function
+func (m *Method) CallThrough(realFn any) {
+	fn := reflect.ValueOf(realFn)
+	if fn.Kind() != reflect.Func {
+		msg := fmt.Sprintf("Tried to set a call through for the mock method %s, but the value was not a function", m.name)
+		panic(msg)
+	}
+
+	if m.mock != nil {
+		m.mock.state().callThroughs[m.name] = fn
+	}
+}
+
+// SetSignature declares the signature of the mock method, so the responses specified for it are validated immediately.
+//
+// The signature can be specified as a function value, or as the function reflect type:
+//
+//	m.Method("GetUserCount").SetSignature((func(string) (int, error))(nil))
+//
+// This method panics if the signature is not a function
+func (m *Method) SetSignature(signature any) {
+	t, ok := signature.(reflect.Type)
+	if !ok {
+		t = reflect.TypeOf(signature)
+	}
+
+	if t == nil || t.Kind() != reflect.Func {
+		msg := fmt.Sprintf("Tried to set a signature for the mock method %s, but the value was not a function", m.name)
+		panic(msg)
+	}
+
+	if m.mock != nil {
+		m.mock.SetSignatures(map[string]reflect.Type{m.name: t})
+	}
+}
+
+// SetArgNames declares the names of the method arguments, in order,
+// so the assertion messages about a single argument can name it:
+//
+//	m.Method("GetUser").SetArgNames("ctx", "userID")
+func (m *Method) SetArgNames(names ...string) {
+	if m.mock != nil {
+		m.mock.state().argNames[m.name] = names
 	}
 }
 
 // GetResponse gets the specified response for the method
-func (m *method) GetResponse(args ...any) (res methodResponse) {
+func (m *Method) GetResponse(args ...any) (res MethodResponse) {
 	if m.mock != nil {
 		res = m.mock.GetMethodResponse(m.name, args...)
 	}
@@ -29,11 +138,11 @@ func (m *method) GetResponse(args ...any) (res methodResponse) {
 }
 
 // GetCalls returns the mock method calls
-func (m *method) GetCalls() []MockCall {
+func (m *Method) GetCalls() []MockCall {
 	calls := []MockCall{}
 
 	if m.mock != nil {
-		for _, mockCall := range m.mock.calls {
+		for _, mockCall := range m.mock.callsSince(m.since) {
 			if mockCall.MethodName == m.name {
 				calls = append(calls, mockCall)
 			}
@@ -44,54 +153,175 @@ func (m *method) GetCalls() []MockCall {
 }
 
 // Called returns if the mock method was called
-func (m *method) Called() bool {
+func (m *Method) Called() bool {
 	return len(m.GetCalls()) > 0
 }
 
 // CalledOnce returns if a mock method was called exactly once
-func (m *method) CalledOnce() bool {
+func (m *Method) CalledOnce() bool {
 	return len(m.GetCalls()) == 1
 }
 
 // CalledTimes returns if a mock method was called 'n' times
-func (m *method) CalledTimes(n int) bool {
+func (m *Method) CalledTimes(n int) bool {
 	return len(m.GetCalls()) == n
 }
 
-// CalledWith returns if the mock method was called at least once with the specified arguments
-func (m *method) CalledWith(args ...any) bool {
-	return checkCalledWith(m.GetCalls(), args...)
+// CalledWith returns if the mock method was called at least once with the specified arguments, in any order.
+//
+// Each call argument can only match one of the specified arguments, so CalledWith(1, 1) needs a call with two 1 arguments
+func (m *Method) CalledWith(args ...any) bool {
+	return checkCalledWith(m.equality(), m.GetCalls(), args...)
 }
 
 // CalledWithExactly returns if the mock method was called at least once with exactly the specified arguments,
 // with the same values and in the same order
-func (m *method) CalledWithExactly(args ...any) bool {
-	return checkCalledWithExactly(m.GetCalls(), args...)
+func (m *Method) CalledWithExactly(args ...any) bool {
+	return checkCalledWithExactly(m.equality(), m.GetCalls(), args...)
+}
+
+// CalledWithInOrder returns if the mock method was called at least once with the specified arguments in the same order,
+// but not necessarily next to each other
+func (m *Method) CalledWithInOrder(args ...any) bool {
+	return checkCalledWithInOrder(m.equality(), m.GetCalls(), args...)
+}
+
+// CalledWithArgAt returns if the mock method was called at least once with the specified argument at the position 'i',
+// regardless of the other arguments.
+//
+// The positions start at 0, like the call arguments, and this method panics if the position is negative
+func (m *Method) CalledWithArgAt(i int, arg any) bool {
+	m.validateArgPosition("check", i)
+
+	return checkCalledWithArgAt(m.equality(), m.GetCalls(), i, arg)
+}
+
+// validateArgPosition panics if the position of a method argument is negative
+func (m *Method) validateArgPosition(action string, i int) {
+	if i < 0 {
+		msg := fmt.Sprintf("Tried to %s the argument %d for the mock method %s, but the argument positions start at 0", action, i, m.name)
+		panic(msg)
+	}
 }
 
-type withArgsDef struct {
-	method *method
-	args   []any
+// WithArgs represents the args of a method response definition,
+// that is completed by calling Returns
+type WithArgs struct {
+	method   *Method
+	args     []any
+	argAt    *int
+	isolated bool
+}
+
+// ResetCalls clears the registered calls of the method from the mock
+func (m *Method) ResetCalls() {
+	if m.mock == nil {
+		return
+	}
+
+	s := m.mock.state()
+
+	calls, seqs := []MockCall{}, []int{}
+	for i, call := range s.calls {
+		if call.MethodName != m.name {
+			calls = append(calls, call)
+			seqs = append(seqs, s.callSeqs[i])
+		}
+	}
+	s.calls, s.callSeqs = calls, seqs
+}
+
+// Reset clears the registered calls and the specified responses of the method from the mock
+func (m *Method) Reset() {
+	m.ResetCalls()
+
+	if m.mock == nil {
+		return
+	}
+
+	for key, s := range m.mock.state().stubs {
+		if s.MethodName == m.name {
+			delete(m.mock.state().stubs, key)
+			delete(m.mock.state().responses, key)
+		}
+	}
+	delete(m.mock.state().callThroughs, m.name)
 }
 
 // WithArgs sets the args that the method will use to return a specific response when receiving those args.
 //
 // Call the `Returns` method subsequently to set a method response with specific args
-func (m *method) WithArgs(args ...any) withArgsDef {
-	return withArgsDef{
-		method: m,
-		args:   args,
+func (m *Method) WithArgs(args ...any) WithArgs {
+	if m.mock != nil {
+		m.mock.validateMethod("set args for", m.name)
+		m.mock.validateArgs("set args for", m.name, args)
+	}
+
+	return WithArgs{
+		method:   m,
+		args:     args,
+		isolated: m.isolated,
+	}
+}
+
+// WithArgAt sets an argument that the method will use to return a specific response when receiving it at the position 'i',
+// regardless of the other arguments.
+//
+// The responses specified with WithArgAt are chosen like the ones specified for args with argument matchers.
+// Call the `Returns` method subsequently to set the method response
+func (m *Method) WithArgAt(i int, arg any) WithArgs {
+	m.validateArgPosition("set", i)
+	if m.mock != nil {
+		m.mock.validateMethod("set args for", m.name)
+	}
+
+	return WithArgs{
+		method:   m,
+		args:     []any{arg},
+		argAt:    &i,
+		isolated: m.isolated,
+	}
+}
+
+// key returns the key of the response specified for the args
+func (d WithArgs) key() string {
+	if d.argAt != nil {
+		return mountResponseKey(fmt.Sprintf("%s[%d]", d.method.name, *d.argAt), d.args...)
+	}
+
+	return mountResponseKey(d.method.name, d.args...)
+}
+
+// Isolated makes the response be deep copied on every method call,
+// so a caller that changes the values it received doesn't affect the other callers
+func (d WithArgs) Isolated() WithArgs {
+	d.isolated = true
+
+	return d
+}
+
+// Returns sets the response that the mock method should return when called with the args
+func (d WithArgs) Returns(response ...any) {
+	if d.method != nil && d.method.mock != nil {
+		d.method.mock.validateResponse(d.method.name, response)
+
+		s := d.method.mock.setStub(d.key(), d.method.name, d.args, response)
+		s.isolated = d.isolated
+		s.argAt = d.argAt
 	}
 }
 
-func (d withArgsDef) Returns(response ...any) {
-	if d.method != nil && d.method.mock != nil && d.method.mock.responses != nil {
-		key := mountResponseKey(d.method.name, d.args...)
-		d.method.mock.responses[key] = response
+// ReturnsLazy sets a function that builds the response that the mock method should return when called with the args,
+// which is called on every method call, so each caller gets fresh values
+func (d WithArgs) ReturnsLazy(build func() []any) {
+	if d.method != nil && d.method.mock != nil {
+		s := d.method.mock.setStub(d.key(), d.method.name, d.args, nil)
+		s.lazy = build
+		s.argAt = d.argAt
 	}
 }
 
 // Assert will begin a new assertion for the method.
-func (m *method) Assert(t *testing.T) *methodAssertion {
-	return &methodAssertion{t: t, m: m}
+func (m *Method) Assert(t *testing.T) *MethodAssertion {
+	return &MethodAssertion{t: t, m: m}
 }
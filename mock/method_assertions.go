@@ -5,19 +5,47 @@ import (
 	"testing"
 )
 
-func mountMethodArgAssertionErrMsg(ma *methodAssertion, expectedArgs ...any) string {
+func mountMethodArgAssertionErrMsg(ma *MethodAssertion, unmatched func(call MockCall) []any, expectedArgs ...any) string {
 	verb := "to be"
 	if ma.negation {
 		verb = "not to be"
 	}
 	return mountArgsAssertionErrMsg(
-		fmt.Sprintf("Failed to assert method call arguments.\nExpected method %s %s called with: \n", ma.m.name, verb),
+		fmt.Sprintf("Failed to assert method call arguments.\nExpected method %s %s called with: \n", ma.m.fullName(), verb),
 		ma.m.GetCalls(),
+		unmatched,
 		expectedArgs...,
 	)
 }
 
-func mountMethodCallAssertionErrMsg(ma *methodAssertion, expectedCallN int) (msg string) {
+func mountMethodArgAtAssertionErrMsg(ma *MethodAssertion, i int, expectedArg any) (msg string) {
+	verb := "to be"
+	if ma.negation {
+		verb = "not to be"
+	}
+
+	arg := ma.m.describeArg(i)
+	msg = fmt.Sprintf("Failed to assert method call arguments.\nExpected method %s %s called with the %s: \n  ++ %s\n", ma.m.fullName(), verb, arg, formatArgs([]any{expectedArg}))
+
+	calls := ma.m.GetCalls()
+	if len(calls) == 0 {
+		return fmt.Sprintf("%s\nBut it was not called\n", msg)
+	}
+
+	msg = fmt.Sprintf("%s\nActual calls:\n", msg)
+	for n, call := range calls {
+		if i >= len(call.Args) {
+			msg = fmt.Sprintf("%s[%d]: (no %s)\n", msg, n+1, arg)
+			continue
+		}
+
+		msg = fmt.Sprintf("%s[%d]: %s\n", msg, n+1, formatArgs([]any{call.Args[i]}))
+	}
+
+	return
+}
+
+func mountMethodCallAssertionErrMsg(ma *MethodAssertion, expectedCallN int) (msg string) {
 	verb := "to be"
 	if ma.negation {
 		verb = "not to be"
@@ -28,7 +56,7 @@ func mountMethodCallAssertionErrMsg(ma *methodAssertion, expectedCallN int) (msg
 		times = fmt.Sprintf("%d times", expectedCallN)
 	}
 
-	msg = fmt.Sprintf("Failed to assert method calls.\nExpected method %s %s called %s, ", ma.m.name, verb, times)
+	msg = fmt.Sprintf("Failed to assert method calls.\nExpected method %s %s called %s, ", ma.m.fullName(), verb, times)
 	if ma.negation {
 		msg += "but it was"
 		return
@@ -46,13 +74,46 @@ func mountMethodCallAssertionErrMsg(ma *methodAssertion, expectedCallN int) (msg
 	return
 }
 
-type methodAssertion struct {
+func mountMethodResponsesAssertionErrMsg(ma *MethodAssertion, call string, expectedValues ...any) string {
+	verb := "to have"
+	if ma.negation {
+		verb = "not to have"
+	}
+	return mountResponsesAssertionErrMsg(
+		fmt.Sprintf("Failed to assert method responses.\nExpected %smethod %s %s returned: (%s)\n", call, ma.m.fullName(), verb, formatArgs(expectedValues)),
+		ma.m.GetCalls(),
+	)
+}
+
+func mountMethodReturnedTimesAssertionErrMsg(ma *MethodAssertion, expectedN int) (msg string) {
+	verb := "to have"
+	if ma.negation {
+		verb = "not to have"
+	}
+
+	msg = fmt.Sprintf("Failed to assert method responses.\nExpected method %s %s returned %s, ", ma.m.fullName(), verb, formatTimes(expectedN))
+	if ma.negation {
+		msg += "but it did"
+		return
+	}
+
+	if returnedN := len(returnedCalls(ma.m.GetCalls())); returnedN == 0 {
+		msg += "but it never returned"
+	} else {
+		msg += fmt.Sprintf("but it returned %s", formatTimes(returnedN))
+	}
+
+	return
+}
+
+// MethodAssertion represents an assertion on the calls of a mock method
+type MethodAssertion struct {
 	t        *testing.T
-	m        *method
+	m        *Method
 	negation bool
 }
 
-func (ma *methodAssertion) verify(cond bool) bool {
+func (ma *MethodAssertion) verify(cond bool) bool {
 	if ma.negation {
 		return !cond
 	}
@@ -60,37 +121,93 @@ func (ma *methodAssertion) verify(cond bool) bool {
 	return cond
 }
 
+// markVerified marks the method calls that match the condition as verified,
+// unless the assertion is a negation
+func (ma *MethodAssertion) markVerified(match func(call MockCall) bool) {
+	if ma.negation || ma.m.mock == nil {
+		return
+	}
+
+	ma.m.mock.markVerified(ma.m.since, func(call MockCall) bool {
+		return call.MethodName == ma.m.name && match(call)
+	})
+}
+
 // Not sets the method assertion as a negation.
 //
 // When this method is called, the NEGATION of the subsequent assertion will be validated.
-func (ma *methodAssertion) Not() *methodAssertion {
+func (ma *MethodAssertion) Not() *MethodAssertion {
 	ma.negation = true
 	return ma
 }
 
 // CalledWith asserts that the method was called at least once with the specified arguments
-func (ma *methodAssertion) CalledWith(args ...any) *finishedMethodAssertion {
+func (ma *MethodAssertion) CalledWith(args ...any) *FinishedMethodAssertion {
 	failureCond := !ma.m.CalledWith(args...)
 	if ma.verify(failureCond) {
-		ma.t.Error(mountMethodArgAssertionErrMsg(ma, args...))
+		ma.t.Error(mountMethodArgAssertionErrMsg(ma, func(call MockCall) []any {
+			return unmatchedArgs(ma.m.equality(), call, args...)
+		}, args...))
 	}
 
-	return &finishedMethodAssertion{ma}
+	ma.markVerified(func(call MockCall) bool {
+		return calledWith(ma.m.equality(), call, args...)
+	})
+
+	return &FinishedMethodAssertion{ma}
 }
 
 // CalledWithExactly asserts that the method was called at least once with exactly the specified arguments,
 // with the same values and in the same order
-func (ma *methodAssertion) CalledWithExactly(args ...any) *finishedMethodAssertion {
+func (ma *MethodAssertion) CalledWithExactly(args ...any) *FinishedMethodAssertion {
 	failureCond := !ma.m.CalledWithExactly(args...)
 	if ma.verify(failureCond) {
-		ma.t.Error(mountMethodArgAssertionErrMsg(ma, args...))
+		ma.t.Error(mountMethodArgAssertionErrMsg(ma, nil, args...))
+	}
+
+	ma.markVerified(func(call MockCall) bool {
+		return checkCalledWithExactly(ma.m.equality(), []MockCall{call}, args...)
+	})
+
+	return &FinishedMethodAssertion{ma}
+}
+
+// CalledWithInOrder asserts that the method was called at least once with the specified arguments in the same order,
+// but not necessarily next to each other
+func (ma *MethodAssertion) CalledWithInOrder(args ...any) *FinishedMethodAssertion {
+	failureCond := !ma.m.CalledWithInOrder(args...)
+	if ma.verify(failureCond) {
+		ma.t.Error(mountMethodArgAssertionErrMsg(ma, func(call MockCall) []any {
+			return unmatchedArgsInOrder(ma.m.equality(), call, args...)
+		}, args...))
 	}
 
-	return &finishedMethodAssertion{ma}
+	ma.markVerified(func(call MockCall) bool {
+		return calledWithInOrder(ma.m.equality(), call, args...)
+	})
+
+	return &FinishedMethodAssertion{ma}
+}
+
+// CalledWithArgAt asserts that the method was called at least once with the specified argument at the position 'i',
+// regardless of the other arguments.
+//
+// The positions start at 0, like the call arguments, and this method panics if the position is negative
+func (ma *MethodAssertion) CalledWithArgAt(i int, arg any) *FinishedMethodAssertion {
+	failureCond := !ma.m.CalledWithArgAt(i, arg)
+	if ma.verify(failureCond) {
+		ma.t.Error(mountMethodArgAtAssertionErrMsg(ma, i, arg))
+	}
+
+	ma.markVerified(func(call MockCall) bool {
+		return calledWithArgAt(ma.m.equality(), call, i, arg)
+	})
+
+	return &FinishedMethodAssertion{ma}
 }
 
 // Called asserts that the method was called at least once
-func (ma *methodAssertion) Called() *finishedMethodAssertion {
+func (ma *MethodAssertion) Called() *FinishedMethodAssertion {
 	wasCalled := ma.m.Called()
 	failureCond := !wasCalled
 	if ma.verify(failureCond) {
@@ -103,41 +220,100 @@ func (ma *methodAssertion) Called() *finishedMethodAssertion {
 		if wasCalled {
 			sufix = "but it was"
 		}
-		ma.t.Errorf("Failed to assert method calls.\nExpected method %s %s called, %s", ma.m.name, verb, sufix)
+		ma.t.Errorf("Failed to assert method calls.\nExpected method %s %s called, %s", ma.m.fullName(), verb, sufix)
 	}
 
-	return &finishedMethodAssertion{ma}
+	ma.markVerified(verifyAll)
+
+	return &FinishedMethodAssertion{ma}
 }
 
 // Called asserts that the method was called exaclty once
-func (ma *methodAssertion) CalledOnce() *finishedMethodAssertion {
+func (ma *MethodAssertion) CalledOnce() *FinishedMethodAssertion {
 	failureCond := !ma.m.CalledOnce()
 	if ma.verify(failureCond) {
 		msg := mountMethodCallAssertionErrMsg(ma, 1)
 		ma.t.Errorf(msg)
 	}
 
-	return &finishedMethodAssertion{ma}
+	ma.markVerified(verifyAll)
+
+	return &FinishedMethodAssertion{ma}
 }
 
 // Called asserts that the method was called 'n' times
-func (ma *methodAssertion) CalledTimes(n int) *finishedMethodAssertion {
+func (ma *MethodAssertion) CalledTimes(n int) *FinishedMethodAssertion {
 	failureCond := !ma.m.CalledTimes(n)
 	if ma.verify(failureCond) {
 		msg := mountMethodCallAssertionErrMsg(ma, n)
 		ma.t.Errorf(msg)
 	}
 
-	return &finishedMethodAssertion{ma}
+	ma.markVerified(verifyAll)
+
+	return &FinishedMethodAssertion{ma}
+}
+
+// ReturnedWith asserts that the method returned exactly the specified values at least once,
+// with the same values and in the same order.
+//
+// Only the calls registered with GetResponseAndRegister have their responses recorded
+func (ma *MethodAssertion) ReturnedWith(values ...any) *FinishedMethodAssertion {
+	failureCond := !checkReturnedWith(ma.m.equality(), ma.m.GetCalls(), values...)
+	if ma.verify(failureCond) {
+		ma.t.Error(mountMethodResponsesAssertionErrMsg(ma, "", values...))
+	}
+
+	ma.markVerified(func(call MockCall) bool {
+		return returnedWith(ma.m.equality(), call, values...)
+	})
+
+	return &FinishedMethodAssertion{ma}
+}
+
+// NthReturnedWith asserts that the 'n'th method call returned exactly the specified values,
+// with the same values and in the same order.
+//
+// The first call is the call 1
+func (ma *MethodAssertion) NthReturnedWith(n int, values ...any) *FinishedMethodAssertion {
+	failureCond := !checkNthReturnedWith(ma.m.equality(), ma.m.GetCalls(), n, values...)
+	if ma.verify(failureCond) {
+		ma.t.Error(mountMethodResponsesAssertionErrMsg(ma, fmt.Sprintf("the call %d of the ", n), values...))
+	}
+
+	// the method calls are matched in order, so counting them finds the 'n'th call
+	i := 0
+	ma.markVerified(func(call MockCall) bool {
+		i++
+		return i == n && returnedWith(ma.m.equality(), call, values...)
+	})
+
+	return &FinishedMethodAssertion{ma}
+}
+
+// ReturnedTimes asserts that the method returned 'n' times
+func (ma *MethodAssertion) ReturnedTimes(n int) *FinishedMethodAssertion {
+	failureCond := len(returnedCalls(ma.m.GetCalls())) != n
+	if ma.verify(failureCond) {
+		ma.t.Error(mountMethodReturnedTimesAssertionErrMsg(ma, n))
+	}
+
+	ma.markVerified(func(call MockCall) bool {
+		return call.returned
+	})
+
+	return &FinishedMethodAssertion{ma}
 }
 
-type finishedMethodAssertion struct {
-	ma *methodAssertion
+// FinishedMethodAssertion represents a method assertion that was already made,
+// and can be chained with another one by calling And
+type FinishedMethodAssertion struct {
+	ma *MethodAssertion
 }
 
 // And is used to chain method assertions
-func (fma *finishedMethodAssertion) And() *methodAssertion {
-	return &methodAssertion{
+func (fma *FinishedMethodAssertion) And() *MethodAssertion {
+	return &MethodAssertion{
 		t: fma.ma.t,
 		m: fma.ma.m,
 	}
@@ -2,20 +2,95 @@ package mock
 
 import (
 	"fmt"
+	"strings"
 )
 
-// methodResponse represents a response that a mock method should return
-type methodResponse []any
+// MethodResponse represents a response that a mock method should return
+type MethodResponse []any
+
+// responseContext describes the mock method call a response was returned for,
+// so the response panic messages can point to it
+type responseContext struct {
+	mockName   string
+	methodName string
+	args       []any
+	// source describes where the response came from, like the location where it was specified
+	source string
+}
+
+// withContext returns a copy of the response with the context attached.
+//
+// The context is stored right after the response values, on the slice spare capacity,
+// so the response values (and its length) remain the same
+func (mr MethodResponse) withContext(ctx *responseContext) MethodResponse {
+	res := make(MethodResponse, len(mr), len(mr)+1)
+	copy(res, mr)
+
+	return append(res, ctx)[:len(mr)]
+}
+
+// context returns the context attached to the response, or nil if there is none
+func (mr MethodResponse) context() *responseContext {
+	if cap(mr) <= len(mr) {
+		return nil
+	}
+
+	ctx, _ := mr[:len(mr)+1][len(mr)].(*responseContext)
+	return ctx
+}
+
+// describeCall returns the method call the response was returned for,
+// like userRepo.GetUser("u1")
+func (ctx *responseContext) describeCall() string {
+	args := make([]string, len(ctx.args))
+	for i, arg := range ctx.args {
+		args[i] = renderValue(arg)
+	}
+
+	name := ctx.methodName
+	if ctx.mockName != "" {
+		name = fmt.Sprintf("%s.%s", ctx.mockName, ctx.methodName)
+	}
+
+	return fmt.Sprintf("%s(%s)", name, strings.Join(args, ", "))
+}
+
+func (mr MethodResponse) panicNoValue(typeName string, i int) {
+	ctx := mr.context()
+	if ctx == nil {
+		msg := fmt.Sprintf("Tried to find a %s value on the index %d of the mock method response, but the index had no value", typeName, i)
+		panic(msg)
+	}
+
+	msg := fmt.Sprintf("%s: index %d expected %s, but the response had %d values (%s)", ctx.describeCall(), i, typeName, len(mr), ctx.source)
+	panic(msg)
+}
+
+func (mr MethodResponse) panicWrongType(typeName string, i int) {
+	ctx := mr.context()
+	if ctx == nil {
+		msg := fmt.Sprintf("Tried to find a %s value on the index %d of the mock method response, but the index value was not an %s", typeName, i, typeName)
+		panic(msg)
+	}
+
+	got := "nil"
+	if mr[i] != nil {
+		got = fmt.Sprintf("%T %s", mr[i], renderValue(mr[i]))
+	}
+
+	msg := fmt.Sprintf("%s: index %d expected %s, got %s (%s)", ctx.describeCall(), i, typeName, got, ctx.source)
+	panic(msg)
+}
 
 // IsEmpty returns if the method response is empty
-func (mr methodResponse) IsEmpty() bool {
+func (mr MethodResponse) IsEmpty() bool {
 	return len(mr) <= 0
 }
 
 // Get returns the response value specified in the method response on the 'i' index.
 //
 // A nil value will be returned if no response value is found on the specified index
-func (mr methodResponse) Get(i int) any {
+func (mr MethodResponse) Get(i int) any {
 	if len(mr) < i+1 {
 		return nil
 	}
@@ -26,16 +101,14 @@ func (mr methodResponse) Get(i int) any {
 // GetBool returns a bool value that should be specified in the method response on the 'i' index.
 //
 // This method panics if no response value is found on the specified index, or the value type is wrong
-func (mr methodResponse) GetBool(i int) bool {
+func (mr MethodResponse) GetBool(i int) bool {
 	if len(mr) < i+1 {
-		msg := fmt.Sprintf("Tried to find a bool value on the index %d of the mock method response, but the index had no value", i)
-		panic(msg)
+		mr.panicNoValue("bool", i)
 	}
 
 	val, ok := mr[i].(bool)
 	if !ok {
-		msg := fmt.Sprintf("Tried to find a bool value on the index %d of the mock method response, but the index value was not an bool", i)
-		panic(msg)
+		mr.panicWrongType("bool", i)
 	}
 
 	return val
@@ -44,16 +117,14 @@ func (mr methodResponse) GetBool(i int) bool {
 // GetString returns a string value that should be specified in the method response on the 'i' index.
 //
 // This method panics if no response value is found on the specified index, or the value type is wrong
-func (mr methodResponse) GetString(i int) string {
+func (mr MethodResponse) GetString(i int) string {
 	if len(mr) < i+1 {
-		msg := fmt.Sprintf("Tried to find a string value on the index %d of the mock method response, but the index had no value", i)
-		panic(msg)
+		mr.panicNoValue("string", i)
 	}
 
 	val, ok := mr[i].(string)
 	if !ok {
-		msg := fmt.Sprintf("Tried to find a string value on the index %d of the mock method response, but the index value was not an string", i)
-		panic(msg)
+		mr.panicWrongType("string", i)
 	}
 
 	return val
@@ -62,16 +133,14 @@ func (mr methodResponse) GetString(i int) string {
 // GetInt returns a int value that should be specified in the method response on the 'i' index.
 //
 // This method panics if no response value is found on the specified index, or the value type is wrong
-func (mr methodResponse) GetInt(i int) int {
+func (mr MethodResponse) GetInt(i int) int {
 	if len(mr) < i+1 {
-		msg := fmt.Sprintf("Tried to find a int value on the index %d of the mock method response, but the index had no value", i)
-		panic(msg)
+		mr.panicNoValue("int", i)
 	}
 
 	val, ok := mr[i].(int)
 	if !ok {
-		msg := fmt.Sprintf("Tried to find a int value on the index %d of the mock method response, but the index value was not an int", i)
-		panic(msg)
+		mr.panicWrongType("int", i)
 	}
 
 	return val
@@ -80,16 +149,14 @@ func (mr methodResponse) GetInt(i int) int {
 // GetInt8 returns a int8 value that should be specified in the method response on the 'i' index.
 //
 // This method panics if no response value is found on the specified index, or the value type is wrong
-func (mr methodResponse) GetInt8(i int) int8 {
+func (mr MethodResponse) GetInt8(i int) int8 {
 	if len(mr) < i+1 {
-		msg := fmt.Sprintf("Tried to find a int8 value on the index %d of the mock method response, but the index had no value", i)
-		panic(msg)
+		mr.panicNoValue("int8", i)
 	}
 
 	val, ok := mr[i].(int8)
 	if !ok {
-		msg := fmt.Sprintf("Tried to find a int8 value on the index %d of the mock method response, but the index value was not an int8", i)
-		panic(msg)
+		mr.panicWrongType("int8", i)
 	}
 
 	return val
@@ -98,16 +165,14 @@ func (mr methodResponse) GetInt8(i int) int8 {
 // GetInt16 returns a int16 value that should be specified in the method response on the 'i' index.
 //
 // This method panics if no response value is found on the specified index, or the value type is wrong
-func (mr methodResponse) GetInt16(i int) int16 {
+func (mr MethodResponse) GetInt16(i int) int16 {
 	if len(mr) < i+1 {
-		msg := fmt.Sprintf("Tried to find a int16 value on the index %d of the mock method response, but the index had no value", i)
-		panic(msg)
+		mr.panicNoValue("int16", i)
 	}
 
 	val, ok := mr[i].(int16)
 	if !ok {
-		msg := fmt.Sprintf("Tried to find a int16 value on the index %d of the mock method response, but the index value was not an int16", i)
-		panic(msg)
+		mr.panicWrongType("int16", i)
 	}
 
 	return val
@@ -116,16 +181,14 @@ func (mr methodResponse) GetInt16(i int) int16 {
 // GetInt32 returns a int32 value that should be specified in the method response on the 'i' index.
 //
 // This method panics if no response value is found on the specified index, or the value type is wrong
-func (mr methodResponse) GetInt32(i int) int32 {
+func (mr MethodResponse) GetInt32(i int) int32 {
 	if len(mr) < i+1 {
-		msg := fmt.Sprintf("Tried to find a int32 value on the index %d of the mock method response, but the index had no value", i)
-		panic(msg)
+		mr.panicNoValue("int32", i)
 	}
 
 	val, ok := mr[i].(int32)
 	if !ok {
-		msg := fmt.Sprintf("Tried to find a int32 value on the index %d of the mock method response, but the index value was not an int32", i)
-		panic(msg)
+		mr.panicWrongType("int32", i)
 	}
 
 	return val
@@ -134,16 +197,14 @@ func (mr methodResponse) GetInt32(i int) int32 {
 // GetInt64 returns a int64 value that should be specified in the method response on the 'i' index.
 //
 // This method panics if no response value is found on the specified index, or the value type is wrong
-func (mr methodResponse) GetInt64(i int) int64 {
+func (mr MethodResponse) GetInt64(i int) int64 {
 	if len(mr) < i+1 {
-		msg := fmt.Sprintf("Tried to find a int64 value on the index %d of the mock method response, but the index had no value", i)
-		panic(msg)
+		mr.panicNoValue("int64", i)
 	}
 
 	val, ok := mr[i].(int64)
 	if !ok {
-		msg := fmt.Sprintf("Tried to find a int64 value on the index %d of the mock method response, but the index value was not an int64", i)
-		panic(msg)
+		mr.panicWrongType("int64", i)
 	}
 
 	return val
@@ -152,16 +213,14 @@ func (mr methodResponse) GetInt64(i int) int64 {
 // GetFloat32 returns a float32 value that should be specified in the method response on the 'i' index.
 //
 // This method panics if no response value is found on the specified index, or the value type is wrong
-func (mr methodResponse) GetFloat32(i int) float32 {
+func (mr MethodResponse) GetFloat32(i int) float32 {
 	if len(mr) < i+1 {
-		msg := fmt.Sprintf("Tried to find a float32 value on the index %d of the mock method response, but the index had no value", i)
-		panic(msg)
+		mr.panicNoValue("float32", i)
 	}
 
 	val, ok := mr[i].(float32)
 	if !ok {
-		msg := fmt.Sprintf("Tried to find a float32 value on the index %d of the mock method response, but the index value was not an float32", i)
-		panic(msg)
+		mr.panicWrongType("float32", i)
 	}
 
 	return val
@@ -170,16 +229,14 @@ func (mr methodResponse) GetFloat32(i int) float32 {
 // GetFloat64 returns a float64 value that should be specified in the method response on the 'i' index.
 //
 // This method panics if no response value is found on the specified index, or the value type is wrong
-func (mr methodResponse) GetFloat64(i int) float64 {
+func (mr MethodResponse) GetFloat64(i int) float64 {
 	if len(mr) < i+1 {
-		msg := fmt.Sprintf("Tried to find a float64 value on the index %d of the mock method response, but the index had no value", i)
-		panic(msg)
+		mr.panicNoValue("float64", i)
 	}
 
 	val, ok := mr[i].(float64)
 	if !ok {
-		msg := fmt.Sprintf("Tried to find a float64 value on the index %d of the mock method response, but the index value was not an float64", i)
-		panic(msg)
+		mr.panicWrongType("float64", i)
 	}
 
 	return val
@@ -190,10 +247,9 @@ func (mr methodResponse) GetFloat64(i int) float64 {
 // (Nil is also considered a valid error)
 //
 // This method panics if no response value is found on the specified index, or the value type is wrong
-func (mr methodResponse) GetError(i int) error {
+func (mr MethodResponse) GetError(i int) error {
 	if len(mr) < i+1 {
-		msg := fmt.Sprintf("Tried to find a error value on the index %d of the mock method response, but the index had no value", i)
-		panic(msg)
+		mr.panicNoValue("error", i)
 	}
 
 	if mr[i] == nil {
@@ -202,8 +258,7 @@ func (mr methodResponse) GetError(i int) error {
 
 	val, ok := mr[i].(error)
 	if !ok {
-		msg := fmt.Sprintf("Tried to find a error value on the index %d of the mock method response, but the index value was not an error", i)
-		panic(msg)
+		mr.panicWrongType("error", i)
 	}
 
 	return val
@@ -2,13 +2,14 @@ package mock
 
 import (
 	"fmt"
+	"runtime"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 )
 
 func TestGet(t *testing.T) {
-	mr := methodResponse{"value1"}
+	mr := MethodResponse{"value1"}
 	t.Run("Should return nil if the index has no value", func(t *testing.T) {
 		assert.Nil(t, mr.Get(1))
 	})
@@ -18,7 +19,7 @@ func TestGet(t *testing.T) {
 }
 
 func TestGetBool(t *testing.T) {
-	mr := methodResponse{true, "value2"}
+	mr := MethodResponse{true, "value2"}
 	t.Run("Should panic with correct message if the index value is not an bool", func(t *testing.T) {
 		assert.PanicsWithValue(t,
 			"Tried to find a bool value on the index 1 of the mock method response, but the index value was not an bool",
@@ -41,7 +42,7 @@ func TestGetBool(t *testing.T) {
 }
 
 func TestGetString(t *testing.T) {
-	mr := methodResponse{"myValue", 10}
+	mr := MethodResponse{"myValue", 10}
 
 	t.Run("Should panic with correct message if the index value is not an string", func(t *testing.T) {
 		assert.PanicsWithValue(t,
@@ -65,7 +66,7 @@ func TestGetString(t *testing.T) {
 }
 
 func TestGetInt(t *testing.T) {
-	mr := methodResponse{42, "value2"}
+	mr := MethodResponse{42, "value2"}
 
 	t.Run("Should panic with correct message if the index value is not an int", func(t *testing.T) {
 		assert.PanicsWithValue(t,
@@ -89,7 +90,7 @@ func TestGetInt(t *testing.T) {
 }
 
 func TestGetInt8(t *testing.T) {
-	mr := methodResponse{int8(42), "value2"}
+	mr := MethodResponse{int8(42), "value2"}
 
 	t.Run("Should panic with correct message if the index value is not an int", func(t *testing.T) {
 		assert.PanicsWithValue(t,
@@ -113,7 +114,7 @@ func TestGetInt8(t *testing.T) {
 }
 
 func TestGetInt16(t *testing.T) {
-	mr := methodResponse{int16(42), "value2"}
+	mr := MethodResponse{int16(42), "value2"}
 
 	t.Run("Should panic with correct message if the index value is not an int", func(t *testing.T) {
 		assert.PanicsWithValue(t,
@@ -137,7 +138,7 @@ func TestGetInt16(t *testing.T) {
 }
 
 func TestGetInt32(t *testing.T) {
-	mr := methodResponse{int32(42), "value2"}
+	mr := MethodResponse{int32(42), "value2"}
 
 	t.Run("Should panic with correct message if the index value is not an int", func(t *testing.T) {
 		assert.PanicsWithValue(t,
@@ -161,7 +162,7 @@ func TestGetInt32(t *testing.T) {
 }
 
 func TestGetInt64(t *testing.T) {
-	mr := methodResponse{int64(42), "value2"}
+	mr := MethodResponse{int64(42), "value2"}
 
 	t.Run("Should panic with correct message if the index value is not an int", func(t *testing.T) {
 		assert.PanicsWithValue(t,
@@ -185,7 +186,7 @@ func TestGetInt64(t *testing.T) {
 }
 
 func TestGetFloat32(t *testing.T) {
-	mr := methodResponse{float32(42), "value2"}
+	mr := MethodResponse{float32(42), "value2"}
 
 	t.Run("Should panic with correct message if the index value is not an int", func(t *testing.T) {
 		assert.PanicsWithValue(t,
@@ -209,7 +210,7 @@ func TestGetFloat32(t *testing.T) {
 }
 
 func TestGetFloat64(t *testing.T) {
-	mr := methodResponse{float64(42), "value2"}
+	mr := MethodResponse{float64(42), "value2"}
 
 	t.Run("Should panic with correct message if the index value is not an int", func(t *testing.T) {
 		assert.PanicsWithValue(t,
@@ -234,7 +235,7 @@ func TestGetFloat64(t *testing.T) {
 
 func TestMethodResponseGetError(t *testing.T) {
 	err := fmt.Errorf("error")
-	mr := methodResponse{err, "value2", nil}
+	mr := MethodResponse{err, "value2", nil}
 
 	t.Run("Should panic with correct message if the index value is not an error", func(t *testing.T) {
 		assert.PanicsWithValue(t,
@@ -257,3 +258,61 @@ func TestMethodResponseGetError(t *testing.T) {
 		assert.Nil(t, mr.GetError(2))
 	})
 }
+
+func TestResponseContext(t *testing.T) {
+	t.Run("Should keep the response values when attaching a context", func(t *testing.T) {
+		mr := MethodResponse{"value1", 2}
+
+		res := mr.withContext(&responseContext{methodName: "GetUser"})
+
+		assert.Equal(t, mr, res)
+		assert.Equal(t, 2, len(res))
+		assert.Equal(t, "GetUser", res.context().methodName)
+		assert.Nil(t, mr.context())
+	})
+	t.Run("Should mention the method call and the stub origin on the panic messages", func(t *testing.T) {
+		m := NewMock()
+		_, _, line, _ := runtime.Caller(0)
+		m.Method("GetUserCount").SetResponse("5")
+
+		res := m.GetMethodResponse("GetUserCount", "u1")
+
+		assert.PanicsWithValue(t,
+			fmt.Sprintf(`GetUserCount("u1"): index 0 expected int, got string "5" (stubbed at method_response_test.go:%d)`, line+1),
+			func() {
+				_ = res.GetInt(0)
+			},
+		)
+		assert.PanicsWithValue(t,
+			fmt.Sprintf(`GetUserCount("u1"): index 1 expected error, but the response had 1 values (stubbed at method_response_test.go:%d)`, line+1),
+			func() {
+				_ = res.GetError(1)
+			},
+		)
+	})
+	t.Run("Should mention the mock name on the panic messages", func(t *testing.T) {
+		m := NewNamedMock("userRepo")
+
+		res := m.GetMethodResponse("GetUser", 42, nil)
+
+		assert.PanicsWithValue(t,
+			"userRepo.GetUser(42, nil): index 1 expected error, but the response had 0 values (no response was specified)",
+			func() {
+				_ = res.GetError(1)
+			},
+		)
+	})
+	t.Run("Should mention the real implementation on the panic messages", func(t *testing.T) {
+		m := NewMock()
+		m.Method("GetUser").CallThrough(func() any { return nil })
+
+		res := m.GetMethodResponse("GetUser")
+
+		assert.PanicsWithValue(t,
+			"GetUser(): index 0 expected string, got nil (returned by the real implementation)",
+			func() {
+				_ = res.GetString(0)
+			},
+		)
+	})
+}
@@ -1,6 +1,9 @@
 package mock
 
 import (
+	"errors"
+	"regexp"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -11,14 +14,14 @@ func TestSetResponse(t *testing.T) {
 		m := NewMock()
 
 		method := m.Method("MyFunc")
-		res := methodResponse{"res1", "res2"}
+		res := MethodResponse{"res1", "res2"}
 
 		method.SetResponse(res[0], res[1])
 
-		assert.Equal(t, res, m.responses[method.name])
+		assert.Equal(t, res, m.state().responses[method.name])
 	})
 	t.Run("Should not break if method mock is nil", func(t *testing.T) {
-		m := method{}
+		m := Method{}
 
 		assert.Nil(t, m.mock)
 		m.SetResponse(42)
@@ -37,14 +40,14 @@ func TestGetResponse(t *testing.T) {
 		m := NewMock()
 		method := m.Method("MyFunc")
 
-		res := methodResponse{"res1", "res2"}
+		res := MethodResponse{"res1", "res2"}
 		method.SetResponse(res[0], res[1])
 
 		actual := method.GetResponse()
 		assert.Equal(t, res, actual)
 	})
 	t.Run("Should not break if method mock value is nil", func(t *testing.T) {
-		m := method{}
+		m := Method{}
 
 		assert.Nil(t, m.mock)
 		m.GetResponse()
@@ -53,7 +56,7 @@ func TestGetResponse(t *testing.T) {
 
 func TestGetMethodCalls(t *testing.T) {
 	t.Run("Should return empty and dont break if the method mock is nil", func(t *testing.T) {
-		m := method{
+		m := Method{
 			name: "MyMethod",
 		}
 
@@ -237,6 +240,111 @@ func TestMethodCalledWith(t *testing.T) {
 	})
 }
 
+func TestMethodCalledWithInOrder(t *testing.T) {
+	t.Run("Should consider only the method calls", func(t *testing.T) {
+		mock := NewMock()
+		mock.RegisterMethodCall("MyFunc1", "MyArg", 10)
+		mock.RegisterMethodCall("MyFunc2", 10, "MyArg")
+
+		assert.True(t, mock.Method("MyFunc1").CalledWithInOrder("MyArg", 10))
+		assert.False(t, mock.Method("MyFunc2").CalledWithInOrder("MyArg", 10))
+		assert.True(t, mock.Method("MyFunc2").CalledWithInOrder(10, "MyArg"))
+
+		mock.Method("MyFunc1").Assert(t).CalledWithInOrder("MyArg", 10)
+		mock.Method("MyFunc2").Assert(t).Not().CalledWithInOrder("MyArg", 10)
+		mock.Assert(t).CalledWithInOrder(10, "MyArg")
+		assert.True(t, mock.state().isVerified(0))
+		assert.True(t, mock.state().isVerified(1))
+	})
+}
+
+func TestMethodCalledWithArgAt(t *testing.T) {
+	t.Run("Should return if the method was called with the argument at the position", func(t *testing.T) {
+		mock := NewMock()
+		mock.RegisterMethodCall("Find", "ctx", "u1", 10)
+		mock.RegisterMethodCall("Other", "ctx", "u2")
+		method := mock.Method("Find")
+
+		assert.True(t, method.CalledWithArgAt(1, "u1"))
+		assert.True(t, method.CalledWithArgAt(2, MatchType[int]{}))
+		assert.False(t, method.CalledWithArgAt(0, "u1"))
+		assert.False(t, method.CalledWithArgAt(1, "u2"))
+		assert.False(t, method.CalledWithArgAt(3, MatchAny{}))
+
+		method.Assert(t).CalledWithArgAt(1, "u1").And().Not().CalledWithArgAt(1, "u2")
+		assert.True(t, mock.state().isVerified(0))
+		assert.False(t, mock.state().isVerified(1))
+	})
+	t.Run("Should panic on negative positions", func(t *testing.T) {
+		mock := NewMock()
+		mock.RegisterMethodCall("Find", "ctx", "u1")
+		method := mock.Method("Find")
+
+		assert.PanicsWithValue(t, "Tried to check the argument -1 for the mock method Find, but the argument positions start at 0", func() {
+			method.CalledWithArgAt(-1, "u1")
+		})
+		assert.PanicsWithValue(t, "Tried to check the argument -1 for the mock method Find, but the argument positions start at 0", func() {
+			method.Assert(t).CalledWithArgAt(-1, "u1")
+		})
+		assert.PanicsWithValue(t, "Tried to check the argument -1 for the mock method Find, but the argument positions start at 0", func() {
+			method.Assert(t).Not().CalledWithArgAt(-1, "u1")
+		})
+	})
+	t.Run("Should name the argument position on the assertion error", func(t *testing.T) {
+		mock := NewNamedMock("userRepo")
+		mock.RegisterMethodCall("Find", "ctx", "u1")
+		mock.RegisterMethodCall("Find", "ctx")
+		method := mock.Method("Find")
+		ma := method.Assert(t)
+
+		assert.Equal(t, "Failed to assert method call arguments.\n"+
+			"Expected method userRepo.Find to be called with the argument 1: \n"+
+			"  ++ \"u2\"\n"+
+			"\nActual calls:\n"+
+			"[1]: \"u1\"\n"+
+			"[2]: (no argument 1)\n", mountMethodArgAtAssertionErrMsg(ma, 1, "u2"))
+
+		method.SetSignature((func(string, string) error)(nil))
+		method.SetArgNames("ctx", "userID")
+
+		assert.Contains(t, mountMethodArgAtAssertionErrMsg(ma, 1, "u2"), "called with the argument 1 (userID string): \n")
+	})
+}
+
+func TestWithArgAt(t *testing.T) {
+	t.Run("Should return the response specified for the argument at the position", func(t *testing.T) {
+		m := NewMock()
+		m.Method("Find").WithArgAt(1, "u1").Returns("user 1")
+		m.Method("Find").WithArgAt(1, MatchType[string]{}).Returns("any user")
+		m.Method("Find").WithArgs("ctx", "u1", 10).Returns("exact user 1")
+		m.Method("Find").SetResponse("default")
+
+		assert.Equal(t, "exact user 1", m.GetResponseAndRegister("Find", "ctx", "u1", 10).GetString(0))
+		assert.Equal(t, "any user", m.GetResponseAndRegister("Find", "ctx", "u1").GetString(0))
+		assert.Equal(t, "default", m.GetResponseAndRegister("Find", "ctx").GetString(0))
+
+		m.Method("Find").WithArgAt(1, "u1").Returns("user 1 again")
+
+		assert.Equal(t, "user 1 again", m.GetResponseAndRegister("Find", "other ctx", "u1", 42).GetString(0))
+		assert.Equal(t, "any user", m.GetResponseAndRegister("Find", "ctx", "u2").GetString(0))
+	})
+	t.Run("Should not mix the responses for an argument with the exact args responses", func(t *testing.T) {
+		m := NewMock()
+		m.Method("Find").WithArgAt(0, "u1").ReturnsLazy(func() []any { return []any{"lazy user 1"} })
+
+		assert.Equal(t, "lazy user 1", m.GetResponseAndRegister("Find", "u1", 10).GetString(0))
+		assert.True(t, m.GetResponseAndRegister("Find", 10, "u1").IsEmpty())
+		assert.Equal(t, `Find(argument 0: "u1") -> (lazy response)`, m.state().stubs[mountResponseKey("Find[0]", "u1")].String())
+	})
+	t.Run("Should panic on negative positions", func(t *testing.T) {
+		m := NewMock()
+
+		assert.PanicsWithValue(t, "Tried to set the argument -1 for the mock method Find, but the argument positions start at 0", func() {
+			m.Method("Find").WithArgAt(-1, "u1")
+		})
+	})
+}
+
 func TestMethodCalledWithExactly(t *testing.T) {
 	t.Run("Should return false if the method was not called with the arguments", func(t *testing.T) {
 		mock := NewMock()
@@ -367,4 +475,295 @@ func TestResponseWithArgs(t *testing.T) {
 		assert.False(t, response4.IsEmpty())
 		assert.Equal(t, return4, response4.Get(0))
 	})
+	t.Run("Should consider the argument matchers when returning a response", func(t *testing.T) {
+		mock := NewMock()
+		method := mock.Method("MyMethod")
+
+		method.WithArgs(MatchRegex{regexp.MustCompile("^admin")}, MatchAny{}).Returns("admin")
+		method.WithArgs(MatchType[string]{}, 42).Returns("any string with 42")
+		method.WithArgs("admin1", 42).Returns("exact")
+		method.WithArgs(MatchAny{}, 42).Returns("anything with 42")
+		method.SetResponse("default")
+
+		assert.Equal(t, MethodResponse{"exact"}, method.GetResponse("admin1", 42))
+		assert.Equal(t, MethodResponse{"anything with 42"}, method.GetResponse("admin2", 42))
+		assert.Equal(t, MethodResponse{"admin"}, method.GetResponse("admin2", 10))
+		assert.Equal(t, MethodResponse{"default"}, method.GetResponse("user", 10))
+		assert.Equal(t, MethodResponse{"default"}, method.GetResponse("admin2"))
+	})
+}
+
+func TestCallThrough(t *testing.T) {
+	t.Run("Should call the real implementation when no response was specified", func(t *testing.T) {
+		m := NewMock()
+		method := m.Method("Sum")
+		method.CallThrough(func(a, b int) int {
+			return a + b
+		})
+
+		res := m.GetResponseAndRegister("Sum", 1, 2)
+
+		assert.Equal(t, MethodResponse{3}, res)
+		assert.True(t, method.CalledWithExactly(1, 2))
+	})
+	t.Run("Should prefer the specified responses over the real implementation", func(t *testing.T) {
+		m := NewMock()
+		method := m.Method("Sum")
+		method.CallThrough(func(a, b int) int {
+			return a + b
+		})
+		method.WithArgs(1, 2).Returns(42)
+
+		assert.Equal(t, MethodResponse{42}, method.GetResponse(1, 2))
+		assert.Equal(t, MethodResponse{7}, method.GetResponse(3, 4))
+
+		method.SetResponse(10)
+		assert.Equal(t, MethodResponse{10}, method.GetResponse(3, 4))
+	})
+	t.Run("Should support variadic implementations", func(t *testing.T) {
+		m := NewMock()
+		method := m.Method("Join")
+		method.CallThrough(func(sep string, parts ...string) string {
+			return strings.Join(parts, sep)
+		})
+
+		assert.Equal(t, MethodResponse{"a-b"}, method.GetResponse("-", "a", "b"))
+		assert.Equal(t, MethodResponse{"a-b"}, method.GetResponse("-", []string{"a", "b"}))
+		assert.Equal(t, MethodResponse{""}, method.GetResponse("-"))
+	})
+	t.Run("Should convert nil arguments to zero values", func(t *testing.T) {
+		m := NewMock()
+		method := m.Method("Check")
+		method.CallThrough(func(err error) bool {
+			return err == nil
+		})
+
+		assert.Equal(t, MethodResponse{true}, method.GetResponse(nil))
+	})
+	t.Run("Should panic if the value is not a function", func(t *testing.T) {
+		m := NewMock()
+
+		assert.PanicsWithValue(t,
+			"Tried to set a call through for the mock method MyMethod, but the value was not a function",
+			func() {
+				m.Method("MyMethod").CallThrough(42)
+			},
+		)
+	})
+	t.Run("Should panic if the arguments do not match the real implementation", func(t *testing.T) {
+		m := NewMock()
+		method := m.Method("Sum")
+		method.CallThrough(func(a, b int) int {
+			return a + b
+		})
+
+		assert.PanicsWithValue(t,
+			"Tried to call through the mock method Sum with 1 arguments, but the real implementation receives 2",
+			func() {
+				method.GetResponse(1)
+			},
+		)
+		assert.PanicsWithValue(t,
+			"Tried to call through the mock method Sum with a string value on the argument 1, but the real implementation expects a int",
+			func() {
+				method.GetResponse(1, "2")
+			},
+		)
+	})
+	t.Run("Should not break if method mock is nil", func(t *testing.T) {
+		m := Method{}
+
+		assert.Nil(t, m.mock)
+		m.CallThrough(func() {})
+	})
+}
+
+func TestMethodResetCalls(t *testing.T) {
+	t.Run("Should clear only the method calls", func(t *testing.T) {
+		m := NewMock()
+		m.RegisterMethodCall("Get", "u1")
+		m.RegisterMethodCall("Save", "u1")
+		m.RegisterMethodCall("Get", "u2")
+		m.Method("Get").SetResponse("user")
+
+		m.Method("Get").ResetCalls()
+
+		assert.Equal(t, []MockCall{{MethodName: "Save", Args: []any{"u1"}}}, m.state().calls)
+		assert.Equal(t, MethodResponse{"user"}, m.Method("Get").GetResponse())
+	})
+	t.Run("Should not break if method mock is nil", func(t *testing.T) {
+		m := Method{}
+
+		m.ResetCalls()
+	})
+}
+
+func TestMethodReset(t *testing.T) {
+	t.Run("Should clear only the method calls and responses", func(t *testing.T) {
+		m := NewMock()
+		m.RegisterMethodCall("Get", "u1")
+		m.RegisterMethodCall("Save", "u1")
+		m.Method("Get").SetResponse("user")
+		m.Method("Get").WithArgs("u1").Returns("user 1")
+		m.Method("Get").CallThrough(func(string) string { return "real user" })
+		m.Method("Save").SetResponse(nil)
+
+		m.Method("Get").Reset()
+
+		assert.Equal(t, []MockCall{{MethodName: "Save", Args: []any{"u1"}}}, m.state().calls)
+		assert.Empty(t, m.Method("Get").GetResponse("u1"))
+		assert.Equal(t, map[string]MethodResponse{"Save": {nil}}, m.state().responses)
+		assert.Empty(t, m.state().callThroughs)
+	})
+	t.Run("Should not break if method mock is nil", func(t *testing.T) {
+		m := Method{}
+
+		m.Reset()
+	})
+}
+
+func TestMethodReturnedWith(t *testing.T) {
+	t.Run("Should record the response and the stub on the registered calls", func(t *testing.T) {
+		m := NewMock()
+		m.Method("GetUser").WithArgs("u1").Returns("user 1", nil)
+		m.Method("GetUser").SetResponse("default user", nil)
+
+		m.GetResponseAndRegister("GetUser", "u1")
+		m.GetResponseAndRegister("GetUser", "u2")
+		m.GetResponseAndRegister("Delete", "u1")
+
+		calls := m.GetCalls()
+		assert.Equal(t, MethodResponse{"user 1", nil}, calls[0].Response)
+		assert.Equal(t, []any{"u1"}, calls[0].Stub.Args)
+		assert.Equal(t, MethodResponse{"default user", nil}, calls[1].Response)
+		assert.Nil(t, calls[1].Stub.Args)
+		assert.Empty(t, calls[2].Response)
+		assert.Nil(t, calls[2].Stub)
+	})
+	t.Run("Should assert the responses returned by the method", func(t *testing.T) {
+		m := NewMock()
+		mockErr := errors.New("mock error")
+		m.Method("GetUser").WithArgs("u1").Returns("user 1", nil)
+		m.Method("GetUser").WithArgs("u2").Returns("", mockErr)
+
+		m.GetResponseAndRegister("GetUser", "u1")
+		m.GetResponseAndRegister("GetUser", "u2")
+		m.RegisterMethodCall("GetUser", "u3")
+
+		m.Method("GetUser").Assert(t).
+			ReturnedWith("user 1", nil).
+			And().
+			ReturnedWith(MatchAny{}, mockErr).
+			And().
+			NthReturnedWith(2, "", mockErr).
+			And().
+			ReturnedTimes(2)
+		m.Method("GetUser").Assert(t).Not().ReturnedWith("user 2", nil)
+		m.Method("GetUser").Assert(t).Not().ReturnedWith("user 1")
+		m.Method("GetUser").Assert(t).Not().NthReturnedWith(1, "", mockErr)
+		m.Method("GetUser").Assert(t).Not().NthReturnedWith(3)
+		m.Method("GetUser").Assert(t).Not().NthReturnedWith(4, "user 1", nil)
+		m.Method("GetUser").Assert(t).Not().ReturnedTimes(3)
+	})
+	t.Run("Should mount a message with the method responses", func(t *testing.T) {
+		m := NewNamedMock("userRepo")
+		m.Method("GetUser").SetResponse("user", nil)
+
+		m.GetResponseAndRegister("GetUser", "u1")
+		m.RegisterMethodCall("GetUser", "u2")
+
+		ma := m.Method("GetUser").Assert(t)
+		assert.Equal(t,
+			"Failed to assert method responses.\n"+
+				"Expected the call 2 of the method userRepo.GetUser to have returned: (\"user\", <nil>)\n"+
+				"\nActual responses:\n"+
+				"[1]: GetUser(\"u1\") -> (\"user\", <nil>)\n"+
+				"[2]: GetUser(\"u2\") (no recorded response)\n",
+			mountMethodResponsesAssertionErrMsg(ma, "the call 2 of the ", "user", nil),
+		)
+		assert.Equal(t,
+			"Failed to assert method responses.\nExpected method userRepo.GetUser to have returned 2 times, but it returned once",
+			mountMethodReturnedTimesAssertionErrMsg(ma, 2),
+		)
+	})
+}
+
+func TestIsolatedResponses(t *testing.T) {
+	t.Run("Should share the response values between the calls by default", func(t *testing.T) {
+		m := NewMock()
+		m.Method("GetOrders").SetResponse([]string{"o1"})
+
+		m.GetMethodResponse("GetOrders").Get(0).([]string)[0] = "changed"
+
+		assert.Equal(t, []string{"changed"}, m.GetMethodResponse("GetOrders").Get(0))
+	})
+	t.Run("Should copy the response values on every call of an isolated method", func(t *testing.T) {
+		m := NewMock()
+		m.Method("GetOrders").Isolated().SetResponse([]string{"o1"}, nil)
+		m.Method("GetOrders").Isolated().WithArgs("u1").Returns(map[string]int{"o1": 1}, nil)
+
+		m.GetMethodResponse("GetOrders").Get(0).([]string)[0] = "changed"
+		m.GetMethodResponse("GetOrders", "u1").Get(0).(map[string]int)["o1"] = 2
+
+		assert.Equal(t, MethodResponse{[]string{"o1"}, nil}, m.GetMethodResponse("GetOrders"))
+		assert.Equal(t, MethodResponse{map[string]int{"o1": 1}, nil}, m.GetMethodResponse("GetOrders", "u1"))
+	})
+	t.Run("Should copy the response values of an isolated response with args", func(t *testing.T) {
+		m := NewMock()
+		m.Method("GetOrders").WithArgs("u1").Isolated().Returns([]string{"o1"})
+		m.Method("GetOrders").WithArgs("u2").Returns([]string{"o2"})
+
+		m.GetMethodResponse("GetOrders", "u1").Get(0).([]string)[0] = "changed"
+		m.GetMethodResponse("GetOrders", "u2").Get(0).([]string)[0] = "changed"
+
+		assert.Equal(t, []string{"o1"}, m.GetMethodResponse("GetOrders", "u1").Get(0))
+		assert.Equal(t, []string{"changed"}, m.GetMethodResponse("GetOrders", "u2").Get(0))
+	})
+	t.Run("Should not make the method isolated", func(t *testing.T) {
+		m := NewMock()
+		method := m.Method("GetOrders")
+
+		method.Isolated()
+
+		assert.False(t, method.isolated)
+	})
+}
+
+func TestLazyResponses(t *testing.T) {
+	t.Run("Should build the response on every call", func(t *testing.T) {
+		m := NewMock()
+		builds := 0
+		m.Method("GetOrders").SetLazyResponse(func() []any {
+			builds++
+			return []any{[]string{"o1"}, builds}
+		})
+
+		first := m.GetResponseAndRegister("GetOrders")
+		first.Get(0).([]string)[0] = "changed"
+		second := m.GetResponseAndRegister("GetOrders")
+
+		assert.Equal(t, MethodResponse{[]string{"o1"}, 2}, second)
+		assert.Equal(t, 2, builds)
+		m.Method("GetOrders").Assert(t).NthReturnedWith(1, []string{"changed"}, 1)
+		assert.Empty(t, m.UnusedStubs())
+	})
+	t.Run("Should build the response specified with args on every call", func(t *testing.T) {
+		m := NewMock()
+		m.Method("GetOrders").WithArgs(MatchAny{}).ReturnsLazy(func() []any {
+			return []any{[]string{"o1"}}
+		})
+		m.Method("GetOrders").SetResponse([]string{"default"})
+
+		res := m.GetMethodResponse("GetOrders", "u1")
+
+		assert.Equal(t, MethodResponse{[]string{"o1"}}, res)
+		assert.Equal(t, MethodResponse{[]string{"default"}}, m.GetMethodResponse("GetOrders"))
+		assert.Empty(t, m.UnmatchedCalls())
+	})
+	t.Run("Should describe the lazy responses", func(t *testing.T) {
+		m := NewMock()
+		m.Method("GetOrders").WithArgs("u1").ReturnsLazy(func() []any { return nil })
+
+		assert.Equal(t, `GetOrders("u1") -> (lazy response)`, m.UnusedStubs()[0].String())
+	})
 }
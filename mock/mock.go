@@ -1,17 +1,153 @@
 package mock
 
-import "testing"
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
 
-// Mock represents a mock and its use information
+// Mock represents a mock and its use information.
+//
+// The zero value of a Mock is ready to use, and its state lives behind a shared pointer,
+// so copies of an initialized mock register their calls and responses on the same state.
+// Copies made before the mock is used don't share anything though,
+// so prefer passing the mock around as a pointer (go vet reports the copies)
 type Mock struct {
-	responses map[string]methodResponse
-	calls     []MockCall
+	noCopy noCopy
+	s      *mockState
+}
+
+// mockState represents the mock use information, shared by every copy of the mock
+type mockState struct {
+	name         string
+	responses    map[string]MethodResponse
+	stubs        map[string]*Stub
+	callThroughs map[string]reflect.Value
+	signatures   map[string]reflect.Type
+	argNames     map[string][]string
+	boundTo      reflect.Type
+	recording    bool
+	recordings   []recordedCall
+	argWatches   map[argWatch]bool
+	watchedCalls []watchedCall
+	equality     *equality
+	calls        []MockCall
+	// callSeqs holds the sequence number of each call, so the checkpoints keep referring
+	// to the same point in the calls history, even after some of the calls are reset
+	callSeqs []int
+	nextSeq  int
+	// verified holds the sequence numbers of the calls already verified by an assertion
+	verified map[int]bool
+	// matched holds the sequence numbers of the calls that had a specified response when it was looked up
+	matched map[int]bool
+}
+
+// appendCall registers a call on the mock state, with the next sequence number
+func (s *mockState) appendCall(call MockCall) {
+	s.calls = append(s.calls, call)
+	s.callSeqs = append(s.callSeqs, s.nextSeq)
+	s.nextSeq++
+}
+
+// isVerified returns if the call 'i' was already verified by an assertion
+func (s *mockState) isVerified(i int) bool {
+	return s.verified[s.callSeqs[i]]
+}
+
+// callsSince returns the mock calls registered from the 'since' sequence number
+func (mock *Mock) callsSince(since int) []MockCall {
+	s := mock.state()
+
+	calls := []MockCall{}
+	for i, call := range s.calls {
+		if s.callSeqs[i] >= since {
+			calls = append(calls, call)
+		}
+	}
+
+	return calls
 }
 
+// noCopy makes go vet report the copies of the structs that contain it, through the copylocks check
+type noCopy struct{}
+
+func (*noCopy) Lock()   {}
+func (*noCopy) Unlock() {}
+
 // NewMock returns a new mock struct
 func NewMock() Mock {
-	return Mock{
-		responses: make(map[string]methodResponse),
+	return Mock{s: newMockState("")}
+}
+
+func newMockState(name string) *mockState {
+	return &mockState{
+		name:         name,
+		responses:    make(map[string]MethodResponse),
+		stubs:        make(map[string]*Stub),
+		callThroughs: make(map[string]reflect.Value),
+		signatures:   make(map[string]reflect.Type),
+		argNames:     make(map[string][]string),
+		verified:     make(map[int]bool),
+		matched:      make(map[int]bool),
+		equality:     &equality{},
+	}
+}
+
+// state returns the mock state, initializing it if the mock is a zero value
+func (mock *Mock) state() *mockState {
+	if mock.s == nil {
+		mock.s = newMockState("")
+	}
+
+	return mock.s
+}
+
+// NewNamedMock returns a new mock struct with a name.
+//
+// The name is used on every assertion message, call dump and response panic message,
+// making them easier to read when a test uses many mocks
+func NewNamedMock(name string) Mock {
+	return Mock{s: newMockState(name)}
+}
+
+// NewMockFor returns a new mock struct bound to the interface I.
+//
+// Besides validating the responses against the interface method signatures (see SetSignatures),
+// a bound mock panics when an unknown method name is used on RegisterMethodCall, Method, SetMethodResponse or WithArgs,
+// suggesting the closest valid method name, and when a method call is registered with arguments that don't match the method signature
+func NewMockFor[I any]() Mock {
+	s := newMockState("")
+	s.boundTo = reflect.TypeOf((*I)(nil)).Elem()
+	for name, signature := range Signatures[I]() {
+		s.signatures[name] = signature
+	}
+
+	return Mock{s: s}
+}
+
+// SetName sets the mock name, that is used on every assertion message, call dump and response panic message
+func (mock *Mock) SetName(name string) {
+	mock.state().name = name
+}
+
+// describe returns how the mock should be referred to on messages
+func (mock *Mock) describe() string {
+	if mock.state().name == "" {
+		return "mock"
+	}
+
+	return fmt.Sprintf("mock %s", mock.state().name)
+}
+
+// SetSignatures declares the signatures of the mock methods, mapped by the method name.
+//
+// When a method has a declared signature, the responses specified for it are validated immediately,
+// and the mock panics if they don't match the method response in length and type.
+//
+// Use the Signatures function to get the signatures from an interface type
+func (mock *Mock) SetSignatures(signatures map[string]reflect.Type) {
+	for name, signature := range signatures {
+		mock.state().signatures[name] = signature
 	}
 }
 
@@ -22,89 +158,348 @@ func NewMock() Mock {
 // of the same type and are in the same order as the method
 // response specified in the method signature
 func (mock *Mock) SetMethodResponse(methodName string, response ...any) {
-	if mock.responses != nil {
-		mock.responses[methodName] = response
+	mock.validateMethod("set a response for", methodName)
+	mock.validateResponse(methodName, response)
+
+	mock.setStub(methodName, methodName, nil, response)
+}
+
+// GetMethodResponse gets the specified response for a method.
+//
+// The responses specified for the exact args have priority, followed by the responses specified
+// for args with argument matchers (the latest specified first), and then the method default response.
+//
+// If no response was specified, but the method has a real implementation to call through,
+// the response will be the values returned by that implementation when called with the args.
+//
+// When a response is found, the latest registered call of the method with the same args is considered matched (see UnmatchedCalls)
+func (mock *Mock) GetMethodResponse(methodName string, args ...any) (res MethodResponse) {
+	res, _, matched := mock.response(MockCall{MethodName: methodName, Args: args})
+	if matched {
+		mock.matchRegisteredCall(methodName, args)
+	}
+
+	return
+}
+
+// matchRegisteredCall marks the latest call of the method with the specified args as matched,
+// skipping the calls that were already matched or that got their response on registration
+func (mock *Mock) matchRegisteredCall(methodName string, args []any) {
+	s := mock.state()
+	for i := len(s.calls) - 1; i >= 0; i-- {
+		call := s.calls[i]
+		if call.MethodName != methodName || call.returned || s.matched[s.callSeqs[i]] || len(call.Args) != len(args) {
+			continue
+		}
+
+		equal := true
+		for j, arg := range args {
+			equal = equal && s.equality.argsAreEqual(arg, call.Args[j])
+		}
+		if equal {
+			s.matched[s.callSeqs[i]] = true
+			return
+		}
 	}
 }
 
-// GetMethodResponse gets the specified response for a method
-func (mock *Mock) GetMethodResponse(methodName string, args ...any) (res methodResponse) {
-	key := mountResponseKey(methodName, args...)
+// response gets the specified response for a method call, alongside the stub that produced it
+func (mock *Mock) response(call MockCall) (res MethodResponse, stub *Stub, matched bool) {
+	methodName, args := call.MethodName, call.Args
+	ctx := &responseContext{
+		mockName:   mock.state().name,
+		methodName: methodName,
+		args:       args,
+		source:     "no response was specified",
+	}
+
+	if key, ok := mock.findStub(call); ok {
+		mock.hitStub(key)
+
+		res = mock.state().responses[key]
+		if s, ok := mock.state().stubs[key]; ok {
+			ctx.source = fmt.Sprintf("stubbed at %s", s.origin)
+			stub = s
+			res = s.response()
+		}
 
-	res = mock.responses[key]
-	if res.IsEmpty() {
-		res = mock.responses[methodName]
+		return res.withContext(ctx), stub, true
 	}
 
-	return
+	if fn, ok := mock.state().callThroughs[methodName]; ok {
+		res = callThrough(methodName, fn, args...)
+		mock.record(methodName, args, res)
+		ctx.source = "returned by the real implementation"
+
+		return res.withContext(ctx), nil, true
+	}
+
+	return res.withContext(ctx), nil, false
 }
 
 // RegisterMethodCall registers a method call on a mock given the method name
-// and the call arguments
+// and the call arguments.
+//
+// The arguments are deep copied, so the registered call reflects what was actually passed,
+// even if the arguments are changed after the call (see SkipArgCopy)
 func (mock *Mock) RegisterMethodCall(methodName string, args ...any) {
-	mock.calls = append(mock.calls, MockCall{
+	mock.registerCall(MockCall{MethodName: methodName, Args: args})
+}
+
+// RegisterVariadicCall registers a call of a variadic method on a mock, given the method name,
+// the arguments before the variadic ones, and the variadic arguments slice:
+//
+//	func (m *repoMock) Find(ctx context.Context, filters ...Filter) {
+//		m.RegisterVariadicCall("Find", []any{ctx}, filters)
+//	}
+//
+// The variadic arguments are registered spread, but the call can be matched both with the variadic arguments spread,
+// or packed in a single slice, on the assertions and on the responses specified with args.
+// Use MatchVariadic to match the variadic arguments as a whole.
+//
+// This method panics if the variadic arguments are not a slice
+func (mock *Mock) RegisterVariadicCall(methodName string, fixedArgs []any, variadic any) {
+	mock.registerCall(mock.variadicCall(methodName, fixedArgs, variadic))
+}
+
+// variadicCall returns a call of a variadic method, with the variadic arguments spread
+func (mock *Mock) variadicCall(methodName string, fixedArgs []any, variadic any) MockCall {
+	t := reflect.TypeOf(variadic)
+	if variadic == nil {
+		t = reflect.TypeOf([]any{})
+		if signature, ok := mock.state().signatures[methodName]; ok && signature.IsVariadic() {
+			t = signature.In(signature.NumIn() - 1)
+		}
+	}
+	if t.Kind() != reflect.Slice {
+		msg := fmt.Sprintf("Tried to register a variadic call for the mock method %s, but the variadic arguments were a %T, and not a slice", methodName, variadic)
+		panic(msg)
+	}
+
+	args := append([]any{}, fixedArgs...)
+	if v := reflect.ValueOf(variadic); v.IsValid() {
+		for i := 0; i < v.Len(); i++ {
+			args = append(args, v.Index(i).Interface())
+		}
+	}
+
+	return MockCall{
 		MethodName: methodName,
 		Args:       args,
-	})
+		variadic:   t,
+		fixedArgs:  len(fixedArgs),
+	}
+}
+
+// registerCall registers a method call on the mock, copying its args
+func (mock *Mock) registerCall(call MockCall) {
+	mock.validateMethod("register a call for", call.MethodName)
+	mock.validateArgs("register a call for", call.MethodName, call.Args)
+
+	args := call.Args
+	call.Args = copyValues(args)
+	seq := mock.state().nextSeq
+	mock.state().appendCall(call)
+	mock.watchArgs(seq, call.MethodName, args)
 }
 
 // GetResponseAndRegister it's equivalent of calling RegisterMethodCall and GetMethodResponse subsequently.
 //
 // It gets the specified response for a method, given the method name and the args,
 // and also registers a method call given those args.
-func (mock *Mock) GetResponseAndRegister(methodName string, args ...any) (res methodResponse) {
-	mock.RegisterMethodCall(methodName, args...)
+//
+// The response, and the specified response that produced it, are recorded on the registered call,
+// so the values returned by the mock can be asserted later
+func (mock *Mock) GetResponseAndRegister(methodName string, args ...any) MethodResponse {
+	return mock.respondAndRegister(MockCall{MethodName: methodName, Args: args})
+}
+
+// GetVariadicResponseAndRegister it's the equivalent of GetResponseAndRegister for variadic methods,
+// registering the call like RegisterVariadicCall does
+func (mock *Mock) GetVariadicResponseAndRegister(methodName string, fixedArgs []any, variadic any) MethodResponse {
+	return mock.respondAndRegister(mock.variadicCall(methodName, fixedArgs, variadic))
+}
+
+// respondAndRegister registers the method call, and gets its response, recording it on the registered call
+func (mock *Mock) respondAndRegister(call MockCall) (res MethodResponse) {
+	mock.registerCall(call)
+	i := len(mock.state().calls) - 1
 
-	return mock.GetMethodResponse(methodName, args...)
+	res, stub, matched := mock.response(call)
+
+	if calls := mock.state().calls; i < len(calls) {
+		if matched {
+			mock.state().matched[mock.state().callSeqs[i]] = true
+		}
+		calls[i].Response = res
+		calls[i].Stub = stub
+		calls[i].returned = true
+	}
+
+	return res
 }
 
 // GetCalls returns the mock calls
 func (mock *Mock) GetCalls() []MockCall {
-	return mock.calls
+	return mock.state().calls
 }
 
 // Called returns if the mock was called
 func (mock *Mock) Called() bool {
-	return len(mock.calls) > 0
+	return len(mock.state().calls) > 0
 }
 
 // CalledOnce returns if a mock was called exactly once
 func (mock *Mock) CalledOnce() bool {
-	return len(mock.calls) == 1
+	return len(mock.state().calls) == 1
 }
 
 // CalledTimes returns if a mock was called 'n' times
 func (mock *Mock) CalledTimes(n int) bool {
-	return len(mock.calls) == n
+	return len(mock.state().calls) == n
 }
 
-// CalledWith returns if the mock was called at least once with the specified arguments
+// CalledWith returns if the mock was called at least once with the specified arguments, in any order.
+//
+// Each call argument can only match one of the specified arguments, so CalledWith(1, 1) needs a call with two 1 arguments
 func (mock *Mock) CalledWith(args ...any) bool {
-	return checkCalledWith(mock.calls, args...)
+	return checkCalledWith(mock.state().equality, mock.state().calls, args...)
 }
 
 // CalledWithExactly returns if the mock was called at least once with exactly the specified arguments,
 // with the same values and in the same order
 func (mock *Mock) CalledWithExactly(args ...any) bool {
-	return checkCalledWithExactly(mock.calls, args...)
+	return checkCalledWithExactly(mock.state().equality, mock.state().calls, args...)
 }
 
-// Reset resets a mock to an empty state
+// CalledWithInOrder returns if the mock was called at least once with the specified arguments in the same order,
+// but not necessarily next to each other
+func (mock *Mock) CalledWithInOrder(args ...any) bool {
+	return checkCalledWithInOrder(mock.state().equality, mock.state().calls, args...)
+}
+
+// Reset resets a mock to an empty state.
+//
+// The mock name, the declared method signatures and argument names, the interface the mock is bound to,
+// the methods that forbid argument mutation, and how the mock compares values, are kept
 func (mock *Mock) Reset() {
-	*(mock) = NewMock()
+	s := mock.state()
+
+	reset := newMockState(s.name)
+	reset.signatures = s.signatures
+	reset.argNames = s.argNames
+	reset.boundTo = s.boundTo
+	reset.argWatches = s.argWatches
+	reset.watchedCalls = s.watchedCalls
+	reset.equality = s.equality
+	reset.nextSeq = s.nextSeq
+	*s = *reset
+}
+
+// ResetCalls clears all registered method calls from the mock, keeping the specified responses
+func (mock *Mock) ResetCalls() {
+	mock.state().calls = nil
+	mock.state().callSeqs = nil
+	mock.state().verified = make(map[int]bool)
+	mock.state().matched = make(map[int]bool)
+}
+
+// ResetResponses clears all specified responses from the mock, keeping the registered method calls
+func (mock *Mock) ResetResponses() {
+	mock.state().responses = make(map[string]MethodResponse)
+	mock.state().stubs = make(map[string]*Stub)
+	mock.state().callThroughs = make(map[string]reflect.Value)
+}
+
+// Checkpoint marks the current point in the mock calls history.
+//
+// The returned checkpoint can be used to make assertions that only consider
+// the calls registered after the checkpoint was created
+func (mock *Mock) Checkpoint() Checkpoint {
+	return Checkpoint{
+		mock:  mock,
+		since: mock.state().nextSeq,
+	}
 }
 
 // Method filters the mock use information for a specific method
-func (mock *Mock) Method(name string) *method {
-	return &method{
-		name,
-		mock,
+func (mock *Mock) Method(name string) *Method {
+	mock.validateMethod("get", name)
+
+	return &Method{
+		name: name,
+		mock: mock,
 	}
 }
 
-func (mock *Mock) Assert(t *testing.T) *mockAssertion {
-	return &mockAssertion{
+// Assert will begin a new assertion for the mock.
+func (mock *Mock) Assert(t *testing.T) *MockAssertion {
+	return &MockAssertion{
 		t: t,
 		m: mock,
 	}
 }
+
+// validateResponse panics if the response does not match the declared signature of the method,
+// pointing to the line where the response was specified
+func (mock *Mock) validateResponse(methodName string, response []any) {
+	signature, ok := mock.state().signatures[methodName]
+	if !ok {
+		return
+	}
+
+	if problem := checkResponse(signature, response); problem != "" {
+		msg := fmt.Sprintf("Tried to set a response for the mock method %s at %s, but %s", methodName, callerLocation(), problem)
+		panic(msg)
+	}
+}
+
+// validateMethod panics if the mock is bound to an interface, and the method name does not belong to it,
+// suggesting the closest valid method name
+func (mock *Mock) validateMethod(action, methodName string) {
+	if mock.state().boundTo == nil {
+		return
+	}
+
+	if _, ok := mock.state().signatures[methodName]; ok {
+		return
+	}
+
+	names := make([]string, 0, len(mock.state().signatures))
+	for name := range mock.state().signatures {
+		names = append(names, name)
+	}
+
+	msg := fmt.Sprintf("Tried to %s the unknown method %s on a mock for %s at %s", action, methodName, mock.state().boundTo, callerLocation())
+	if suggestion := closestName(methodName, names); suggestion != "" {
+		msg = fmt.Sprintf("%s. Did you mean %s?", msg, suggestion)
+	}
+	panic(msg)
+}
+
+// validateArgs panics if the mock is bound to an interface,
+// and the args do not match the declared signature of the method
+func (mock *Mock) validateArgs(action, methodName string, args []any) {
+	if mock.state().boundTo == nil {
+		return
+	}
+
+	signature, ok := mock.state().signatures[methodName]
+	if !ok {
+		return
+	}
+
+	if problem := checkArgs(signature, args); problem != "" {
+		msg := fmt.Sprintf("Tried to %s the mock method %s at %s, but %s", action, methodName, callerLocation(), problem)
+		panic(msg)
+	}
+}
+
+// markVerified marks the mock calls registered from the 'since' sequence number that match the condition as verified
+func (mock *Mock) markVerified(since int, match func(call MockCall) bool) {
+	for i, call := range mock.state().calls {
+		if mock.state().callSeqs[i] >= since && match(call) {
+			mock.state().verified[mock.state().callSeqs[i]] = true
+		}
+	}
+}
@@ -2,22 +2,24 @@ package mock
 
 import (
 	"fmt"
+	"strings"
 	"testing"
 )
 
-func mountMockArgAssertionErrMsg(ma *mockAssertion, expectedArgs ...any) string {
+func mountMockArgAssertionErrMsg(ma *MockAssertion, unmatched func(call MockCall) []any, expectedArgs ...any) string {
 	verb := "to be"
 	if ma.negation {
 		verb = "not to be"
 	}
 	return mountArgsAssertionErrMsg(
-		fmt.Sprintf("Failed to assert mock call arguments.\nExpected mock %s called with: \n", verb),
-		ma.m.GetCalls(),
+		fmt.Sprintf("Failed to assert mock call arguments.\nExpected %s %s called with: \n", ma.m.describe(), verb),
+		ma.calls(),
+		unmatched,
 		expectedArgs...,
 	)
 }
 
-func mountMockCallAssertionErrMsg(ma *mockAssertion, expectedCallN int) (msg string) {
+func mountMockCallAssertionErrMsg(ma *MockAssertion, expectedCallN int) (msg string) {
 	verb := "to be"
 	if ma.negation {
 		verb = "not to be"
@@ -28,13 +30,13 @@ func mountMockCallAssertionErrMsg(ma *mockAssertion, expectedCallN int) (msg str
 		times = fmt.Sprintf("%d times", expectedCallN)
 	}
 
-	msg = fmt.Sprintf("Failed to assert mock calls.\nExpected mock %s called %s, ", verb, times)
+	msg = fmt.Sprintf("Failed to assert mock calls.\nExpected %s %s called %s, ", ma.m.describe(), verb, times)
 	if ma.negation {
 		msg += "but it was"
 		return
 	}
 
-	switch callsLen := len(ma.m.GetCalls()); callsLen {
+	switch callsLen := len(ma.calls()); callsLen {
 	case 0:
 		msg += "but it was not called"
 	case 1:
@@ -46,21 +48,61 @@ func mountMockCallAssertionErrMsg(ma *mockAssertion, expectedCallN int) (msg str
 	return
 }
 
-type mockAssertion struct {
+func mountMockResponsesAssertionErrMsg(ma *MockAssertion, call string, expectedValues ...any) string {
+	verb := "to have"
+	if ma.negation {
+		verb = "not to have"
+	}
+	return mountResponsesAssertionErrMsg(
+		fmt.Sprintf("Failed to assert mock responses.\nExpected %s%s %s returned: (%s)\n", call, ma.m.describe(), verb, formatArgs(expectedValues)),
+		ma.calls(),
+	)
+}
+
+func mountMockReturnedTimesAssertionErrMsg(ma *MockAssertion, expectedN int) (msg string) {
+	verb := "to have"
+	if ma.negation {
+		verb = "not to have"
+	}
+
+	msg = fmt.Sprintf("Failed to assert mock responses.\nExpected %s %s returned %s, ", ma.m.describe(), verb, formatTimes(expectedN))
+	if ma.negation {
+		msg += "but it did"
+		return
+	}
+
+	if returnedN := len(returnedCalls(ma.calls())); returnedN == 0 {
+		msg += "but it never returned"
+	} else {
+		msg += fmt.Sprintf("but it returned %s", formatTimes(returnedN))
+	}
+
+	return
+}
+
+// MockAssertion represents an assertion on the calls of a mock
+type MockAssertion struct {
 	t        *testing.T
 	m        *Mock
 	negation bool
+	// since is the sequence number of the first mock call considered by the assertion
+	since int
+}
+
+// calls returns the mock calls considered by the assertion
+func (ma *MockAssertion) calls() []MockCall {
+	return ma.m.callsSince(ma.since)
 }
 
 // Not sets the mock assertion as a negation.
 //
 // When this method is called, the NEGATION of the subsequent assertion will be validated.
-func (ma *mockAssertion) Not() *mockAssertion {
+func (ma *MockAssertion) Not() *MockAssertion {
 	ma.negation = true
 	return ma
 }
 
-func (ma *mockAssertion) verify(cond bool) bool {
+func (ma *MockAssertion) verify(cond bool) bool {
 	if ma.negation {
 		return !cond
 	}
@@ -68,30 +110,65 @@ func (ma *mockAssertion) verify(cond bool) bool {
 	return cond
 }
 
+// markVerified marks the mock calls that match the condition as verified,
+// unless the assertion is a negation
+func (ma *MockAssertion) markVerified(match func(call MockCall) bool) {
+	if !ma.negation {
+		ma.m.markVerified(ma.since, match)
+	}
+}
+
 // CalledWith asserts that the mock was called at least once with the specified arguments
-func (ma *mockAssertion) CalledWith(args ...any) *finishedMockAssertion {
-	failureCond := !ma.m.CalledWith(args...)
+func (ma *MockAssertion) CalledWith(args ...any) *FinishedMockAssertion {
+	failureCond := !checkCalledWith(ma.m.state().equality, ma.calls(), args...)
 	if ma.verify(failureCond) {
-		ma.t.Error(mountMockArgAssertionErrMsg(ma, args...))
+		ma.t.Error(mountMockArgAssertionErrMsg(ma, func(call MockCall) []any {
+			return unmatchedArgs(ma.m.state().equality, call, args...)
+		}, args...))
 	}
 
-	return &finishedMockAssertion{ma}
+	ma.markVerified(func(call MockCall) bool {
+		return calledWith(ma.m.state().equality, call, args...)
+	})
+
+	return &FinishedMockAssertion{ma}
 }
 
 // CalledWithExactly asserts that the mock was called at least once with exactly the specified arguments,
 // with the same values and in the same order
-func (ma *mockAssertion) CalledWithExactly(args ...any) *finishedMockAssertion {
-	failureCond := !ma.m.CalledWithExactly(args...)
+func (ma *MockAssertion) CalledWithExactly(args ...any) *FinishedMockAssertion {
+	failureCond := !checkCalledWithExactly(ma.m.state().equality, ma.calls(), args...)
 	if ma.verify(failureCond) {
-		ma.t.Error(mountMockArgAssertionErrMsg(ma, args...))
+		ma.t.Error(mountMockArgAssertionErrMsg(ma, nil, args...))
 	}
 
-	return &finishedMockAssertion{ma}
+	ma.markVerified(func(call MockCall) bool {
+		return checkCalledWithExactly(ma.m.state().equality, []MockCall{call}, args...)
+	})
+
+	return &FinishedMockAssertion{ma}
+}
+
+// CalledWithInOrder asserts that the mock was called at least once with the specified arguments in the same order,
+// but not necessarily next to each other
+func (ma *MockAssertion) CalledWithInOrder(args ...any) *FinishedMockAssertion {
+	failureCond := !checkCalledWithInOrder(ma.m.state().equality, ma.calls(), args...)
+	if ma.verify(failureCond) {
+		ma.t.Error(mountMockArgAssertionErrMsg(ma, func(call MockCall) []any {
+			return unmatchedArgsInOrder(ma.m.state().equality, call, args...)
+		}, args...))
+	}
+
+	ma.markVerified(func(call MockCall) bool {
+		return calledWithInOrder(ma.m.state().equality, call, args...)
+	})
+
+	return &FinishedMockAssertion{ma}
 }
 
 // Called asserts that the mock was called at least once
-func (ma *mockAssertion) Called() *finishedMockAssertion {
-	wasCalled := ma.m.Called()
+func (ma *MockAssertion) Called() *FinishedMockAssertion {
+	wasCalled := len(ma.calls()) > 0
 	failureCond := !wasCalled
 	if ma.verify(failureCond) {
 		verb := "to be"
@@ -103,42 +180,185 @@ func (ma *mockAssertion) Called() *finishedMockAssertion {
 		if wasCalled {
 			sufix = "but it was"
 		}
-		ma.t.Errorf("Failed to assert mock calls.\nExpected mock %s called, %s", verb, sufix)
+		ma.t.Errorf("Failed to assert mock calls.\nExpected %s %s called, %s", ma.m.describe(), verb, sufix)
 	}
 
-	return &finishedMockAssertion{ma}
+	ma.markVerified(verifyAll)
+
+	return &FinishedMockAssertion{ma}
 }
 
 // Called asserts that the mock was called exaclty once
-func (ma *mockAssertion) CalledOnce() *finishedMockAssertion {
-	failureCond := !ma.m.CalledOnce()
+func (ma *MockAssertion) CalledOnce() *FinishedMockAssertion {
+	failureCond := len(ma.calls()) != 1
 	if ma.verify(failureCond) {
 		msg := mountMockCallAssertionErrMsg(ma, 1)
 		ma.t.Errorf(msg)
 	}
 
-	return &finishedMockAssertion{ma}
+	ma.markVerified(verifyAll)
+
+	return &FinishedMockAssertion{ma}
 }
 
 // Called asserts that the mock was called 'n' times
-func (ma *mockAssertion) CalledTimes(n int) *finishedMockAssertion {
-	failureCond := !ma.m.CalledTimes(n)
+func (ma *MockAssertion) CalledTimes(n int) *FinishedMockAssertion {
+	failureCond := len(ma.calls()) != n
 	if ma.verify(failureCond) {
 		msg := mountMockCallAssertionErrMsg(ma, n)
 		ma.t.Errorf(msg)
 	}
 
-	return &finishedMockAssertion{ma}
+	ma.markVerified(verifyAll)
+
+	return &FinishedMockAssertion{ma}
+}
+
+// ReturnedWith asserts that the mock returned exactly the specified values at least once,
+// with the same values and in the same order.
+//
+// Only the calls registered with GetResponseAndRegister have their responses recorded
+func (ma *MockAssertion) ReturnedWith(values ...any) *FinishedMockAssertion {
+	failureCond := !checkReturnedWith(ma.m.state().equality, ma.calls(), values...)
+	if ma.verify(failureCond) {
+		ma.t.Error(mountMockResponsesAssertionErrMsg(ma, "", values...))
+	}
+
+	ma.markVerified(func(call MockCall) bool {
+		return returnedWith(ma.m.state().equality, call, values...)
+	})
+
+	return &FinishedMockAssertion{ma}
+}
+
+// NthReturnedWith asserts that the 'n'th mock call returned exactly the specified values,
+// with the same values and in the same order.
+//
+// The first call is the call 1
+func (ma *MockAssertion) NthReturnedWith(n int, values ...any) *FinishedMockAssertion {
+	failureCond := !checkNthReturnedWith(ma.m.state().equality, ma.calls(), n, values...)
+	if ma.verify(failureCond) {
+		ma.t.Error(mountMockResponsesAssertionErrMsg(ma, fmt.Sprintf("the call %d of the ", n), values...))
+	}
+
+	// the mock calls are matched in order, so counting them finds the 'n'th call
+	i := 0
+	ma.markVerified(func(call MockCall) bool {
+		i++
+		return i == n && returnedWith(ma.m.state().equality, call, values...)
+	})
+
+	return &FinishedMockAssertion{ma}
+}
+
+// ReturnedTimes asserts that the mock returned 'n' times
+func (ma *MockAssertion) ReturnedTimes(n int) *FinishedMockAssertion {
+	failureCond := len(returnedCalls(ma.calls())) != n
+	if ma.verify(failureCond) {
+		ma.t.Error(mountMockReturnedTimesAssertionErrMsg(ma, n))
+	}
+
+	ma.markVerified(func(call MockCall) bool {
+		return call.returned
+	})
+
+	return &FinishedMockAssertion{ma}
+}
+
+// NoUnusedStubs asserts that every response specified on the mock was returned at least once
+func (ma *MockAssertion) NoUnusedStubs() *FinishedMockAssertion {
+	unused := ma.m.UnusedStubs()
+	failureCond := len(unused) > 0
+	if ma.verify(failureCond) {
+		if ma.negation {
+			ma.t.Errorf("Failed to assert mock stubs.\nExpected %s to have unused stubs, but all of them were used", ma.m.describe())
+		} else {
+			msg := fmt.Sprintf("Failed to assert mock stubs.\nExpected %s to have no unused stubs, but the following stubs were never used:\n", ma.m.describe())
+			for _, s := range unused {
+				msg = fmt.Sprintf("%s  -- %s\n", msg, s)
+			}
+			ma.t.Error(msg)
+		}
+	}
+
+	return &FinishedMockAssertion{ma}
+}
+
+// NoUnexpectedCalls asserts that every mock call had a specified response to return
+func (ma *MockAssertion) NoUnexpectedCalls() *FinishedMockAssertion {
+	unmatched := ma.m.UnmatchedCalls()
+	failureCond := len(unmatched) > 0
+	if ma.verify(failureCond) {
+		if ma.negation {
+			ma.t.Errorf("Failed to assert mock calls.\nExpected %s to have unexpected calls, but all of them had a specified response", ma.m.describe())
+		} else {
+			msg := fmt.Sprintf("Failed to assert mock calls.\nExpected %s to have no unexpected calls, but the following calls had no specified response:\n", ma.m.describe())
+			ma.t.Error(mountCallListErrMsg(msg, unmatched))
+		}
+	}
+
+	return &FinishedMockAssertion{ma}
+}
+
+// OnlyCalled asserts that the mock was not called with any other methods than the specified ones
+func (ma *MockAssertion) OnlyCalled(methodNames ...string) *FinishedMockAssertion {
+	unexpected := []MockCall{}
+	for _, call := range ma.calls() {
+		if !containsName(methodNames, call.MethodName) {
+			unexpected = append(unexpected, call)
+		}
+	}
+
+	failureCond := len(unexpected) > 0
+	if ma.verify(failureCond) {
+		if ma.negation {
+			ma.t.Errorf("Failed to assert mock calls.\nExpected %s to be called with other methods than %s, but it was not", ma.m.describe(), strings.Join(methodNames, ", "))
+		} else {
+			msg := fmt.Sprintf("Failed to assert mock calls.\nExpected %s to be called only with the methods %s, but it had the calls:\n", ma.m.describe(), strings.Join(methodNames, ", "))
+			ma.t.Error(mountCallListErrMsg(msg, unexpected))
+		}
+	}
+
+	return &FinishedMockAssertion{ma}
+}
+
+// NoMoreInteractions asserts that every mock call was verified by a previous assertion.
+//
+// A call is verified when a previous CalledWith, CalledWithExactly or CalledWithInOrder assertion matched it,
+// or when a previous Called, CalledOnce or CalledTimes assertion was made on the mock or on the call method
+func (ma *MockAssertion) NoMoreInteractions() *FinishedMockAssertion {
+	unverified := []MockCall{}
+	s := ma.m.state()
+	for i, call := range s.calls {
+		if s.callSeqs[i] >= ma.since && !s.isVerified(i) {
+			unverified = append(unverified, call)
+		}
+	}
+
+	failureCond := len(unverified) > 0
+	if ma.verify(failureCond) {
+		if ma.negation {
+			ma.t.Errorf("Failed to assert mock calls.\nExpected %s to have unverified calls, but all of them were verified", ma.m.describe())
+		} else {
+			msg := fmt.Sprintf("Failed to assert mock calls.\nExpected %s to have no more interactions, but it had the unverified calls:\n", ma.m.describe())
+			ma.t.Error(mountCallListErrMsg(msg, unverified))
+		}
+	}
+
+	return &FinishedMockAssertion{ma}
 }
 
-type finishedMockAssertion struct {
-	ma *mockAssertion
+// FinishedMockAssertion represents a mock assertion that was already made,
+// and can be chained with another one by calling And
+type FinishedMockAssertion struct {
+	ma *MockAssertion
 }
 
 // And is used to chain mock assertions
-func (fma *finishedMockAssertion) And() *mockAssertion {
-	return &mockAssertion{
-		m: fma.ma.m,
-		t: fma.ma.t,
+func (fma *FinishedMockAssertion) And() *MockAssertion {
+	return &MockAssertion{
+		m:     fma.ma.m,
+		t:     fma.ma.t,
+		since: fma.ma.since,
 	}
 }
@@ -1,16 +1,63 @@
 package mock
 
+import "reflect"
+
 // MockCall represents a mock call, with the call arguments
 type MockCall struct {
 	MethodName string
 	Args       []any
+	// Response is the response that the mock returned on the call,
+	// when the call was registered with GetResponseAndRegister
+	Response MethodResponse
+	// Stub is the specified response that produced the call response,
+	// or nil if no specified response matched the call
+	Stub *Stub
+
+	// returned indicates if the call response was recorded
+	returned bool
+	// variadic is the slice type of the variadic arguments, when the call was registered with RegisterVariadicCall
+	variadic reflect.Type
+	// fixedArgs is the number of arguments before the variadic arguments
+	fixedArgs int
+}
+
+// argForms returns the forms the call arguments can be matched in.
+//
+// The arguments of a variadic call can be matched spread, as they were registered,
+// or with the variadic arguments packed in a single slice, as the method received them
+func (mc MockCall) argForms() [][]any {
+	if mc.variadic == nil || mc.fixedArgs > len(mc.Args) {
+		return [][]any{mc.Args}
+	}
+
+	tail := reflect.MakeSlice(mc.variadic, 0, len(mc.Args)-mc.fixedArgs)
+	for _, arg := range mc.Args[mc.fixedArgs:] {
+		val, ok := assignableValue(arg, mc.variadic.Elem())
+		if !ok {
+			return [][]any{mc.Args}
+		}
+		tail = reflect.Append(tail, val)
+	}
+
+	packed := append(append([]any{}, mc.Args[:mc.fixedArgs]...), tail.Interface())
+
+	return [][]any{mc.Args, packed}
 }
 
-// HasArgument returns if a mock call arguments contains a specific argument
+// HasArgument returns if a mock call arguments contains a specific argument.
+//
+// The arguments are compared with the comparers registered with RegisterComparer
 func (mc *MockCall) HasArgument(arg any) bool {
-	for _, a := range mc.Args {
-		if argsAreEqual(arg, a) {
-			return true
+	return mc.hasArgument(nil, arg)
+}
+
+// hasArgument returns if a mock call arguments contains a specific argument, comparing them with the equality
+func (mc *MockCall) hasArgument(eq *equality, arg any) bool {
+	for _, args := range mc.argForms() {
+		for _, a := range args {
+			if eq.argsAreEqual(arg, a) {
+				return true
+			}
 		}
 	}
 
@@ -1,6 +1,7 @@
 package mock
 
 import (
+	"reflect"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -11,10 +12,10 @@ func TestSetMethodResponse(t *testing.T) {
 		m := NewMock()
 
 		fnName := "MyFunc"
-		res := methodResponse{"res1", "res2"}
+		res := MethodResponse{"res1", "res2"}
 		m.SetMethodResponse(fnName, res...)
 
-		actual := m.responses[fnName]
+		actual := m.state().responses[fnName]
 		assert.Equal(t, res, actual)
 	})
 }
@@ -30,7 +31,7 @@ func TestGetMethodResponse(t *testing.T) {
 		m := NewMock()
 
 		fnName := "MyFunc"
-		res := methodResponse{"res1", "res2"}
+		res := MethodResponse{"res1", "res2"}
 		m.SetMethodResponse(fnName, res...)
 
 		actual := m.GetMethodResponse("MyFunc")
@@ -47,11 +48,11 @@ func TestRegisterMethodCall(t *testing.T) {
 		arg2 := 10
 		m.RegisterMethodCall(fnName, arg1, arg2)
 
-		assert.NotEmpty(t, m.calls)
-		assert.Equal(t, 1, len(m.calls))
-		assert.Equal(t, fnName, m.calls[0].MethodName)
-		assert.Equal(t, arg1, m.calls[0].Args[0])
-		assert.Equal(t, arg2, m.calls[0].Args[1])
+		assert.NotEmpty(t, m.state().calls)
+		assert.Equal(t, 1, len(m.state().calls))
+		assert.Equal(t, fnName, m.state().calls[0].MethodName)
+		assert.Equal(t, arg1, m.state().calls[0].Args[0])
+		assert.Equal(t, arg2, m.state().calls[0].Args[1])
 	})
 	t.Run("Should register a method call without arguments correctly", func(t *testing.T) {
 		m := NewMock()
@@ -59,13 +60,100 @@ func TestRegisterMethodCall(t *testing.T) {
 		fnName := "MyFunc"
 		m.RegisterMethodCall(fnName)
 
-		assert.NotEmpty(t, m.calls)
-		assert.Equal(t, 1, len(m.calls))
-		assert.Equal(t, fnName, m.calls[0].MethodName)
-		assert.Empty(t, m.calls[0].Args)
+		assert.NotEmpty(t, m.state().calls)
+		assert.Equal(t, 1, len(m.state().calls))
+		assert.Equal(t, fnName, m.state().calls[0].MethodName)
+		assert.Empty(t, m.state().calls[0].Args)
 	})
 }
 
+type filter struct {
+	Field string
+	Value any
+}
+
+func TestRegisterVariadicCall(t *testing.T) {
+	active := filter{Field: "active", Value: true}
+	named := filter{Field: "name", Value: "John"}
+
+	t.Run("Should register the variadic arguments spread", func(t *testing.T) {
+		m := NewMock()
+		filters := []filter{active, named}
+
+		m.RegisterVariadicCall("Find", []any{"ctx"}, filters)
+		filters[0] = named
+
+		assert.Equal(t, []any{"ctx", active, named}, m.state().calls[0].Args)
+		assert.Equal(t, [][]any{
+			{"ctx", active, named},
+			{"ctx", []filter{active, named}},
+		}, m.state().calls[0].argForms())
+	})
+	t.Run("Should match the variadic arguments either spread or as a slice", func(t *testing.T) {
+		m := NewMock()
+		m.RegisterVariadicCall("Find", []any{"ctx"}, []filter{active, named})
+		m.RegisterVariadicCall("Find", []any{"other ctx"}, []filter{})
+
+		assert.True(t, m.CalledWithExactly("ctx", active, named))
+		assert.True(t, m.CalledWithExactly("ctx", []filter{active, named}))
+		assert.True(t, m.CalledWithExactly("other ctx"))
+		assert.True(t, m.CalledWithExactly("other ctx", []filter{}))
+		assert.True(t, m.CalledWith(named, "ctx"))
+		assert.True(t, m.CalledWith([]filter{active, named}))
+		assert.True(t, m.CalledWithInOrder("ctx", named))
+		assert.True(t, m.Method("Find").CalledWithArgAt(1, []filter{active, named}))
+		assert.True(t, m.GetCalls()[0].HasArgument([]filter{active, named}))
+		assert.False(t, m.CalledWithExactly("ctx", []filter{named, active}))
+		assert.False(t, m.CalledWithExactly("ctx", active))
+		assert.False(t, m.CalledWith([]filter{active, named}, active))
+	})
+	t.Run("Should match the variadic arguments with MatchVariadic", func(t *testing.T) {
+		m := NewMock()
+		m.RegisterVariadicCall("Find", []any{"ctx"}, []filter{active, named})
+		m.RegisterMethodCall("FindSlice", "ctx", []filter{active})
+		m.RegisterVariadicCall("Count", nil, nil)
+
+		assert.True(t, m.CalledWithExactly("ctx", MatchVariadic(active, MatchAny{})))
+		assert.True(t, m.CalledWithExactly("ctx", MatchVariadic(active)))
+		assert.True(t, m.CalledWithExactly(MatchVariadic()))
+		assert.True(t, m.CalledWith(MatchVariadic(active, named)))
+		assert.False(t, m.CalledWithExactly("ctx", MatchVariadic(named, active)))
+		assert.False(t, m.CalledWithExactly(MatchVariadic(active)))
+		assert.Equal(t, `Find("ctx", MatchVariadic({active true}, {}))`, formatCall(MockCall{
+			MethodName: "Find",
+			Args:       []any{"ctx", MatchVariadic(active, MatchAny{})},
+		}))
+	})
+	t.Run("Should choose the response specified for the variadic arguments either spread or as a slice", func(t *testing.T) {
+		m := NewMock()
+		m.Method("Find").WithArgs("ctx", active).Returns("active users")
+		m.Method("Find").WithArgs("ctx", []filter{named}).Returns("named users")
+		m.Method("Find").WithArgs("ctx", MatchVariadic(active, MatchAny{})).Returns("active and other users")
+
+		assert.Equal(t, "active users", m.GetVariadicResponseAndRegister("Find", []any{"ctx"}, []filter{active}).GetString(0))
+		assert.Equal(t, "named users", m.GetVariadicResponseAndRegister("Find", []any{"ctx"}, []filter{named}).GetString(0))
+		assert.Equal(t, "active and other users", m.GetVariadicResponseAndRegister("Find", []any{"ctx"}, []filter{active, named}).GetString(0))
+		assert.True(t, m.GetVariadicResponseAndRegister("Find", []any{"ctx"}, []filter{named, active}).IsEmpty())
+		assert.Equal(t, 4, len(m.GetCalls()))
+		assert.Empty(t, m.UnusedStubs())
+	})
+	t.Run("Should panic if the variadic arguments are not a slice", func(t *testing.T) {
+		m := NewMock()
+
+		assert.PanicsWithValue(t, "Tried to register a variadic call for the mock method Find, but the variadic arguments were a string, and not a slice", func() {
+			m.RegisterVariadicCall("Find", []any{"ctx"}, "filter")
+		})
+	})
+}
+
+// setCalls replaces the mock calls, as if they were registered in order
+func setCalls(m *Mock, calls []MockCall) {
+	m.ResetCalls()
+	for _, call := range calls {
+		m.state().appendCall(call)
+	}
+}
+
 func TestGetCalls(t *testing.T) {
 	t.Run("Should get the mock calls correctly", func(t *testing.T) {
 		m := NewMock()
@@ -73,20 +161,20 @@ func TestGetCalls(t *testing.T) {
 		res := m.GetCalls()
 		assert.Empty(t, res)
 
-		m.calls = []MockCall{
+		setCalls(&m, []MockCall{
 			{
 				MethodName: "MyFunc1",
 			},
 			{
 				MethodName: "MyFunc2",
 			},
-		}
+		})
 
 		res = m.GetCalls()
 		assert.NotEmpty(t, res)
 		assert.Equal(t, 2, len(res))
-		assert.Equal(t, m.calls[0], res[0])
-		assert.Equal(t, m.calls[1], res[1])
+		assert.Equal(t, m.state().calls[0], res[0])
+		assert.Equal(t, m.state().calls[1], res[1])
 	})
 }
 
@@ -97,23 +185,23 @@ func TestCalled(t *testing.T) {
 		res := m.Called()
 		assert.False(t, res)
 
-		m.calls = []MockCall{
+		setCalls(&m, []MockCall{
 			{
 				MethodName: "MyFunc1",
 			},
-		}
+		})
 
 		res = m.Called()
 		assert.True(t, res)
 
-		m.calls = []MockCall{
+		setCalls(&m, []MockCall{
 			{
 				MethodName: "MyFunc1",
 			},
 			{
 				MethodName: "MyFunc2",
 			},
-		}
+		})
 
 		res = m.Called()
 		assert.True(t, res)
@@ -127,23 +215,23 @@ func TestCalledOnce(t *testing.T) {
 		res := m.CalledOnce()
 		assert.False(t, res)
 
-		m.calls = []MockCall{
+		setCalls(&m, []MockCall{
 			{
 				MethodName: "MyFunc1",
 			},
-		}
+		})
 
 		res = m.CalledOnce()
 		assert.True(t, res)
 
-		m.calls = []MockCall{
+		setCalls(&m, []MockCall{
 			{
 				MethodName: "MyFunc1",
 			},
 			{
 				MethodName: "MyFunc2",
 			},
-		}
+		})
 
 		res = m.CalledOnce()
 		assert.False(t, res)
@@ -157,28 +245,28 @@ func TestCalledTimes(t *testing.T) {
 		res := m.CalledTimes(2)
 		assert.False(t, res)
 
-		m.calls = []MockCall{
+		setCalls(&m, []MockCall{
 			{
 				MethodName: "MyFunc1",
 			},
-		}
+		})
 
 		res = m.CalledTimes(2)
 		assert.False(t, res)
 
-		m.calls = []MockCall{
+		setCalls(&m, []MockCall{
 			{
 				MethodName: "MyFunc1",
 			},
 			{
 				MethodName: "MyFunc2",
 			},
-		}
+		})
 
 		res = m.CalledTimes(2)
 		assert.True(t, res)
 
-		m.calls = []MockCall{
+		setCalls(&m, []MockCall{
 			{
 				MethodName: "MyFunc1",
 			},
@@ -188,7 +276,7 @@ func TestCalledTimes(t *testing.T) {
 			{
 				MethodName: "MyFunc3",
 			},
-		}
+		})
 
 		res = m.CalledTimes(2)
 		assert.False(t, res)
@@ -207,22 +295,22 @@ func TestCalledWith(t *testing.T) {
 		res = m.CalledWith(arg1, arg2)
 		assert.False(t, res)
 
-		m.calls = []MockCall{
+		setCalls(&m, []MockCall{
 			{Args: []any{arg1}},
-		}
+		})
 		res = m.CalledWith(arg1, arg2)
 		assert.False(t, res)
 
-		m.calls = []MockCall{
+		setCalls(&m, []MockCall{
 			{Args: []any{arg2}},
-		}
+		})
 		res = m.CalledWith(arg1, arg2)
 		assert.False(t, res)
 
-		m.calls = []MockCall{
+		setCalls(&m, []MockCall{
 			{Args: []any{arg1}},
 			{Args: []any{arg2}},
-		}
+		})
 		res = m.CalledWith(arg1, arg2)
 		assert.False(t, res)
 	})
@@ -231,29 +319,29 @@ func TestCalledWith(t *testing.T) {
 		arg1 := "MyArg"
 		arg2 := 10
 
-		m.calls = []MockCall{
+		setCalls(&m, []MockCall{
 			{Args: []any{arg1, arg2}},
-		}
+		})
 		res := m.CalledWith(arg1, arg2)
 		assert.True(t, res)
 
-		m.calls = []MockCall{
+		setCalls(&m, []MockCall{
 			{Args: []any{arg2, arg1}},
-		}
+		})
 		res = m.CalledWith(arg1, arg2)
 		assert.True(t, res)
 
-		m.calls = []MockCall{
+		setCalls(&m, []MockCall{
 			{Args: []any{arg2, "some other argument", arg1, 42}},
-		}
+		})
 		res = m.CalledWith(arg1, arg2)
 		assert.True(t, res)
 
-		m.calls = []MockCall{
+		setCalls(&m, []MockCall{
 			{Args: []any{42}},
 			{Args: []any{arg1, arg2}},
 			{Args: []any{"some other argument"}},
-		}
+		})
 		res = m.CalledWith(arg1, arg2)
 		assert.True(t, res)
 	})
@@ -261,9 +349,9 @@ func TestCalledWith(t *testing.T) {
 		m := NewMock()
 		sliceArg := []string{"1", "2", "3"}
 
-		m.calls = []MockCall{
+		setCalls(&m, []MockCall{
 			{Args: []any{sliceArg}},
-		}
+		})
 
 		res := m.CalledWith(sliceArg)
 		assert.True(t, res)
@@ -278,9 +366,9 @@ func TestCalledWith(t *testing.T) {
 		m := NewMock()
 		mapArg := map[string]int{"1": 3, "2": 4, "3": 5}
 
-		m.calls = []MockCall{
+		setCalls(&m, []MockCall{
 			{Args: []any{mapArg}},
-		}
+		})
 
 		res := m.CalledWith(mapArg)
 		assert.True(t, res)
@@ -294,6 +382,91 @@ func TestCalledWith(t *testing.T) {
 		res = m.CalledWith(20)
 		assert.False(t, res)
 	})
+	t.Run("Should match each call argument only once", func(t *testing.T) {
+		m := NewMock()
+		setCalls(&m, []MockCall{
+			{Args: []any{1, "MyArg"}},
+		})
+
+		assert.False(t, m.CalledWith(1, 1))
+		assert.False(t, m.CalledWith(MatchType[int]{}, MatchAny{}, MatchAny{}))
+		assert.True(t, m.CalledWith(MatchAny{}, 1))
+		assert.True(t, m.CalledWith(MatchAny{}, MatchType[int]{}))
+
+		setCalls(&m, []MockCall{
+			{Args: []any{1, "MyArg", 1}},
+		})
+
+		assert.True(t, m.CalledWith(1, 1))
+		assert.False(t, m.CalledWith(1, 1, 1))
+	})
+}
+
+func TestCalledWithInOrder(t *testing.T) {
+	t.Run("Should return true if the mock was called with the arguments in the same order", func(t *testing.T) {
+		m := NewMock()
+		setCalls(&m, []MockCall{
+			{Args: []any{"MyArg", 42, "some other argument", 10}},
+		})
+
+		assert.True(t, m.CalledWithInOrder("MyArg", 10))
+		assert.True(t, m.CalledWithInOrder(42, MatchAny{}, 10))
+		assert.True(t, m.CalledWithInOrder("MyArg", 42, "some other argument", 10))
+	})
+	t.Run("Should return false if the mock was not called with the arguments in the same order", func(t *testing.T) {
+		m := NewMock()
+		assert.False(t, m.CalledWithInOrder("MyArg"))
+
+		setCalls(&m, []MockCall{
+			{Args: []any{"MyArg", 42, 10}},
+		})
+
+		assert.False(t, m.CalledWithInOrder(10, "MyArg"))
+		assert.False(t, m.CalledWithInOrder(42, 42))
+		assert.False(t, m.CalledWithInOrder())
+
+		setCalls(&m, []MockCall{
+			{Args: []any{}},
+		})
+
+		assert.True(t, m.CalledWithInOrder())
+	})
+}
+
+func TestUnmatchedArgs(t *testing.T) {
+	t.Run("Should return the expected arguments that were left unmatched", func(t *testing.T) {
+		call := MockCall{Args: []any{1, "MyArg", 10}}
+
+		assert.Equal(t, []any{}, unmatchedArgs(nil, call, MatchType[int]{}, 1))
+		assert.Equal(t, []any{1}, unmatchedArgs(nil, call, 1, 1))
+		assert.Equal(t, []any{42, "other"}, unmatchedArgs(nil, call, 42, "MyArg", "other"))
+	})
+	t.Run("Should return the expected arguments from the first one out of order", func(t *testing.T) {
+		call := MockCall{Args: []any{1, "MyArg", 10}}
+
+		assert.Equal(t, []any{}, unmatchedArgsInOrder(nil, call, 1, 10))
+		assert.Equal(t, []any{1}, unmatchedArgsInOrder(nil, call, 10, 1))
+		assert.Equal(t, []any{42, 10}, unmatchedArgsInOrder(nil, call, "MyArg", 42, 10))
+	})
+	t.Run("Should list the unmatched arguments on the assertion error", func(t *testing.T) {
+		m := NewNamedMock("userRepo")
+		m.RegisterMethodCall("Get", "u1", 1)
+		ma := m.Assert(t)
+
+		msg := mountMockArgAssertionErrMsg(ma, func(call MockCall) []any {
+			return unmatchedArgs(nil, call, "u1", "u1")
+		}, "u1", "u1")
+
+		assert.Equal(t, "Failed to assert mock call arguments.\n"+
+			"Expected mock userRepo to be called with: \n"+
+			"  ++ (string) u1\n"+
+			"  ++ (string) u1\n"+
+			"\nActual calls:\n"+
+			"[1]:\n"+
+			"  -- (string) u1\n"+
+			"  -- (int) 1\n"+
+			"  Unmatched expected arguments: \"u1\"\n", msg)
+	})
 }
 
 func TestCalledWithExactly(t *testing.T) {
@@ -308,22 +481,22 @@ func TestCalledWithExactly(t *testing.T) {
 		res = m.CalledWithExactly(arg1, arg2)
 		assert.False(t, res)
 
-		m.calls = []MockCall{
+		setCalls(&m, []MockCall{
 			{Args: []any{arg1}},
-		}
+		})
 		res = m.CalledWithExactly(arg1, arg2)
 		assert.False(t, res)
 
-		m.calls = []MockCall{
+		setCalls(&m, []MockCall{
 			{Args: []any{arg2}},
-		}
+		})
 		res = m.CalledWithExactly(arg1, arg2)
 		assert.False(t, res)
 
-		m.calls = []MockCall{
+		setCalls(&m, []MockCall{
 			{Args: []any{arg1}},
 			{Args: []any{arg2}},
-		}
+		})
 		res = m.CalledWithExactly(arg1, arg2)
 		assert.False(t, res)
 	})
@@ -332,29 +505,29 @@ func TestCalledWithExactly(t *testing.T) {
 		arg1 := "MyArg"
 		arg2 := 10
 
-		m.calls = []MockCall{
+		setCalls(&m, []MockCall{
 			{Args: []any{arg1, arg2}},
-		}
+		})
 		res := m.CalledWithExactly(arg1, arg2)
 		assert.True(t, res)
 
-		m.calls = []MockCall{
+		setCalls(&m, []MockCall{
 			{Args: []any{arg2, arg1}},
-		}
+		})
 		res = m.CalledWithExactly(arg1, arg2)
 		assert.False(t, res)
 
-		m.calls = []MockCall{
+		setCalls(&m, []MockCall{
 			{Args: []any{arg2, "some other argument", arg1, 42}},
-		}
+		})
 		res = m.CalledWithExactly(arg1, arg2)
 		assert.False(t, res)
 
-		m.calls = []MockCall{
+		setCalls(&m, []MockCall{
 			{Args: []any{42}},
 			{Args: []any{arg1, arg2}},
 			{Args: []any{"some other argument"}},
-		}
+		})
 		res = m.CalledWithExactly(arg1, arg2)
 		assert.True(t, res)
 	})
@@ -362,9 +535,9 @@ func TestCalledWithExactly(t *testing.T) {
 		m := NewMock()
 		sliceArg := []string{"1", "2", "3"}
 
-		m.calls = []MockCall{
+		setCalls(&m, []MockCall{
 			{Args: []any{sliceArg}},
-		}
+		})
 
 		res := m.CalledWithExactly(sliceArg)
 		assert.True(t, res)
@@ -379,9 +552,9 @@ func TestCalledWithExactly(t *testing.T) {
 		m := NewMock()
 		mapArg := map[string]int{"1": 3, "2": 4, "3": 5}
 
-		m.calls = []MockCall{
+		setCalls(&m, []MockCall{
 			{Args: []any{mapArg}},
-		}
+		})
 
 		res := m.CalledWithExactly(mapArg)
 		assert.True(t, res)
@@ -406,9 +579,9 @@ func TestReset(t *testing.T) {
 
 		m.Reset()
 
-		assert.NotNil(t, m.responses)
-		assert.Empty(t, m.responses)
-		assert.Empty(t, m.calls)
+		assert.NotNil(t, m.state().responses)
+		assert.Empty(t, m.state().responses)
+		assert.Empty(t, m.state().calls)
 	})
 }
 
@@ -420,6 +593,233 @@ func TestMethod(t *testing.T) {
 		method := m.Method(fnName)
 
 		assert.Equal(t, fnName, method.name)
-		assert.Equal(t, m, *method.mock)
+		assert.Same(t, &m, method.mock)
+	})
+}
+
+func TestVerifiedCalls(t *testing.T) {
+	t.Run("Should mark the calls matched by the assertions as verified", func(t *testing.T) {
+		m := NewMock()
+		m.RegisterMethodCall("Get", "u1")
+		m.RegisterMethodCall("Get", "u2")
+		m.RegisterMethodCall("Save", "u1", 42)
+
+		m.Assert(t).CalledWithExactly("u1", 42)
+		assert.False(t, m.state().isVerified(0))
+		assert.False(t, m.state().isVerified(1))
+		assert.True(t, m.state().isVerified(2))
+
+		m.Method("Get").Assert(t).CalledWith("u1")
+		assert.True(t, m.state().isVerified(0))
+		assert.False(t, m.state().isVerified(1))
+
+		m.Assert(t).OnlyCalled("Get", "Save")
+		assert.False(t, m.state().isVerified(1))
+
+		m.Method("Get").Assert(t).CalledTimes(2)
+		assert.True(t, m.state().isVerified(1))
+
+		m.Assert(t).NoMoreInteractions()
+	})
+	t.Run("Should not mark calls as verified on negations", func(t *testing.T) {
+		m := NewMock()
+		m.RegisterMethodCall("Get", "u1")
+
+		m.Assert(t).Not().CalledWith("u2")
+		m.Method("Get").Assert(t).Not().CalledTimes(2)
+
+		assert.False(t, m.state().isVerified(0))
+		m.Assert(t).Not().NoMoreInteractions()
+	})
+	t.Run("Should not change the calls returned by GetCalls", func(t *testing.T) {
+		m := NewMock()
+		m.RegisterMethodCall("Save", "u1")
+		expected := []MockCall{{MethodName: "Save", Args: []any{"u1"}}}
+
+		assert.True(t, reflect.DeepEqual(expected, m.GetCalls()))
+		m.Assert(t).Called()
+		assert.True(t, reflect.DeepEqual(expected, m.GetCalls()))
+		assert.True(t, m.state().isVerified(0))
+	})
+	t.Run("Should mark every call as verified when asserting the mock calls", func(t *testing.T) {
+		m := NewMock()
+		m.RegisterMethodCall("Get", "u1")
+		m.RegisterMethodCall("Save", "u1")
+
+		m.Assert(t).Called()
+
+		m.Assert(t).NoMoreInteractions()
+	})
+}
+
+func TestResetCalls(t *testing.T) {
+	t.Run("Should clear the calls, keeping the responses", func(t *testing.T) {
+		m := NewMock()
+
+		m.RegisterMethodCall("MyMethod", 42)
+		m.SetMethodResponse("MyMethod", "response")
+
+		m.ResetCalls()
+
+		assert.Empty(t, m.state().calls)
+		assert.Equal(t, MethodResponse{"response"}, m.GetMethodResponse("MyMethod"))
+	})
+}
+
+func TestResetResponses(t *testing.T) {
+	t.Run("Should clear the responses, keeping the calls", func(t *testing.T) {
+		m := NewMock()
+
+		m.RegisterMethodCall("MyMethod", 42)
+		m.SetMethodResponse("MyMethod", "response")
+		m.Method("MyMethod").WithArgs(42).Returns("specific response")
+		m.Method("Other").CallThrough(func() {})
+
+		m.ResetResponses()
+
+		assert.Empty(t, m.state().responses)
+		assert.Empty(t, m.state().stubs)
+		assert.Empty(t, m.state().callThroughs)
+		assert.True(t, m.CalledWith(42))
+	})
+}
+
+func TestNewNamedMock(t *testing.T) {
+	t.Run("Should return a new mock with the specified name", func(t *testing.T) {
+		m := NewNamedMock("userRepo")
+
+		assert.Equal(t, "userRepo", m.state().name)
+		assert.Equal(t, "mock userRepo", m.describe())
+		assert.NotNil(t, m.state().responses)
+	})
+	t.Run("Should keep the name after a reset", func(t *testing.T) {
+		m := NewNamedMock("userRepo")
+
+		m.Reset()
+
+		assert.Equal(t, "userRepo", m.state().name)
+	})
+}
+
+func TestSetName(t *testing.T) {
+	t.Run("Should set the mock name", func(t *testing.T) {
+		m := NewMock()
+		assert.Equal(t, "mock", m.describe())
+
+		m.SetName("userRepo")
+
+		assert.Equal(t, "userRepo", m.state().name)
+		assert.Equal(t, "userRepo.GetUser", m.Method("GetUser").fullName())
+	})
+}
+
+// copyOf copies a value without go vet noticing, to test the behavior of mock copies
+func copyOf[T any](v *T) T {
+	return *v
+}
+
+func TestZeroValueMock(t *testing.T) {
+	t.Run("Should register calls and responses on a zero value mock", func(t *testing.T) {
+		var m Mock
+
+		m.Method("GetUser").SetResponse("user")
+		m.Method("GetUser").WithArgs("u1").Returns("user 1")
+		res := m.GetResponseAndRegister("GetUser", "u2")
+
+		assert.Equal(t, MethodResponse{"user"}, res)
+		assert.Equal(t, MethodResponse{"user 1"}, m.GetMethodResponse("GetUser", "u1"))
+		assert.True(t, m.Method("GetUser").CalledOnce())
+	})
+	t.Run("Should work embedded on a mock struct", func(t *testing.T) {
+		type repoMock struct {
+			Mock
+		}
+		r := repoMock{}
+
+		r.SetMethodResponse("GetUser", "user")
+		r.Method("GetUser").CallThrough(func(userID string) string { return userID })
+		r.SetSignatures(map[string]reflect.Type{"Delete": reflect.TypeOf((func(string) error)(nil))})
+
+		assert.Equal(t, MethodResponse{"user"}, r.GetResponseAndRegister("GetUser", "u1"))
+		assert.Panics(t, func() {
+			r.Method("Delete").SetResponse(42)
+		})
+	})
+	t.Run("Should be empty after a reset", func(t *testing.T) {
+		var m Mock
+
+		m.Reset()
+
+		assert.False(t, m.Called())
+		assert.True(t, m.GetMethodResponse("GetUser").IsEmpty())
+	})
+}
+
+func TestMockCopies(t *testing.T) {
+	t.Run("Should share the calls and responses between the copies of a mock", func(t *testing.T) {
+		m := NewMock()
+		copied := copyOf(&m)
+
+		copied.Method("GetUser").SetResponse("user")
+		res := copied.GetResponseAndRegister("GetUser", "u1")
+
+		assert.Equal(t, MethodResponse{"user"}, res)
+		assert.True(t, m.Method("GetUser").CalledOnce())
+		assert.Equal(t, MethodResponse{"user"}, m.GetMethodResponse("GetUser"))
+	})
+	t.Run("Should keep sharing the state after a reset", func(t *testing.T) {
+		m := NewNamedMock("userRepo")
+		copied := copyOf(&m)
+		m.RegisterMethodCall("GetUser", "u1")
+
+		copied.Reset()
+		m.RegisterMethodCall("Delete", "u1")
+
+		assert.True(t, copied.Method("Delete").CalledOnce())
+		assert.False(t, copied.Method("GetUser").Called())
+		assert.Equal(t, "mock userRepo", copied.describe())
+	})
+	t.Run("Should not share the state of a clone", func(t *testing.T) {
+		m := NewMock()
+		clone := m.Clone()
+
+		clone.RegisterMethodCall("GetUser", "u1")
+
+		assert.False(t, m.Called())
+	})
+}
+
+func TestReturnedWith(t *testing.T) {
+	t.Run("Should assert the responses returned by the mock", func(t *testing.T) {
+		m := NewMock()
+		m.Method("GetUser").SetResponse("user")
+		m.Method("Delete").SetResponse(nil)
+
+		m.GetResponseAndRegister("GetUser", "u1")
+		m.GetResponseAndRegister("Delete", "u1")
+
+		m.Assert(t).
+			ReturnedWith("user").
+			And().
+			NthReturnedWith(2, nil).
+			And().
+			ReturnedTimes(2)
+		m.Assert(t).Not().ReturnedWith("other user")
+		m.Assert(t).Not().NthReturnedWith(1, nil)
+		m.Assert(t).Not().ReturnedTimes(1)
+		m.Assert(t).NoMoreInteractions()
+	})
+	t.Run("Should only consider the calls registered with their responses", func(t *testing.T) {
+		m := NewMock()
+		m.SetMethodResponse("GetUser", "user")
+
+		m.RegisterMethodCall("GetUser", "u1")
+
+		m.Assert(t).Not().ReturnedWith("user")
+		m.Assert(t).ReturnedTimes(0)
+		assert.Equal(t,
+			"Failed to assert mock responses.\nExpected mock to have returned once, but it never returned",
+			mountMockReturnedTimesAssertionErrMsg(m.Assert(t), 1),
+		)
 	})
 }
@@ -0,0 +1,314 @@
+package mock
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"sync"
+)
+
+// recordedCall represents a call to a real implementation, recorded by the mock
+type recordedCall struct {
+	MethodName string
+	Args       []any
+	Response   MethodResponse
+}
+
+// fixtureValue represents a value stored on a fixture, along with its type name
+type fixtureValue struct {
+	Type  string          `json:"type"`
+	Value json.RawMessage `json:"value,omitempty"`
+	// Match indicates that the value is replayed as an argument matcher, instead of a value,
+	// for the arguments that can't be replayed by their value
+	Match string `json:"match,omitempty"`
+}
+
+type fixtureCall struct {
+	Method   string         `json:"method"`
+	Args     []fixtureValue `json:"args"`
+	Response []fixtureValue `json:"response"`
+}
+
+type fixture struct {
+	Calls []fixtureCall `json:"calls"`
+}
+
+const (
+	nilTypeName   = "nil"
+	errorTypeName = "error"
+
+	// matchAnyName marks an argument that's replayed as MatchAny
+	matchAnyName = "any"
+	// matchContextName marks a context argument, that's replayed as a matcher of any context
+	matchContextName = "context"
+)
+
+var (
+	registeredTypesMu sync.RWMutex
+	registeredTypes   = map[string]reflect.Type{}
+)
+
+func init() {
+	RegisterType[string]()
+	RegisterType[bool]()
+	RegisterType[int]()
+	RegisterType[int8]()
+	RegisterType[int16]()
+	RegisterType[int32]()
+	RegisterType[int64]()
+	RegisterType[uint]()
+	RegisterType[uint8]()
+	RegisterType[uint16]()
+	RegisterType[uint32]()
+	RegisterType[uint64]()
+	RegisterType[float32]()
+	RegisterType[float64]()
+	RegisterType[[]byte]()
+	RegisterType[[]string]()
+	RegisterType[[]int]()
+	RegisterType[map[string]any]()
+}
+
+// RegisterType registers the type T, so values of that type can be decoded from recorded fixtures.
+//
+// The basic types are registered by default, so only custom types (like structs) need to be registered
+func RegisterType[T any]() {
+	t := reflect.TypeOf((*T)(nil)).Elem()
+
+	registeredTypesMu.Lock()
+	defer registeredTypesMu.Unlock()
+
+	registeredTypes[t.String()] = t
+}
+
+// Record makes the mock call through the methods of a real implementation,
+// recording each call arguments and returned values so they can be saved as a fixture with SaveRecording.
+//
+// The methods with a specified response still return it, and those calls are not recorded
+func (mock *Mock) Record(real any) {
+	v := reflect.ValueOf(real)
+	if !v.IsValid() {
+		panic("Tried to record a mock from a real implementation, but the implementation was nil")
+	}
+
+	for i := 0; i < v.NumMethod(); i++ {
+		name := v.Type().Method(i).Name
+		if _, ok := mock.state().signatures[name]; mock.state().boundTo != nil && !ok {
+			continue
+		}
+
+		mock.Method(name).CallThrough(v.Method(i).Interface())
+	}
+
+	mock.state().recording = true
+}
+
+// record registers a call to a real implementation, if the mock is recording.
+//
+// The args and the response are deep copied, so the recording reflects the call even if they are changed afterwards
+func (mock *Mock) record(methodName string, args []any, res MethodResponse) {
+	if !mock.state().recording {
+		return
+	}
+
+	mock.state().recordings = append(mock.state().recordings, recordedCall{
+		MethodName: methodName,
+		Args:       copyValues(args),
+		Response:   copyValues(res),
+	})
+}
+
+// SaveRecording saves the calls recorded since Record was called as a JSON fixture on the specified path.
+//
+// Custom argument types must be registered with RegisterType before saving, otherwise the calls
+// could not be told apart on the replay, and an error naming the type is returned
+func (mock *Mock) SaveRecording(path string) error {
+	f := fixture{Calls: []fixtureCall{}}
+	for _, rec := range mock.state().recordings {
+		args, err := encodeFixtureArgs(rec.Args)
+		if err != nil {
+			return fmt.Errorf("failed to encode the arguments of a %s call: %w", rec.MethodName, err)
+		}
+
+		res, err := encodeFixtureValues(rec.Response)
+		if err != nil {
+			return fmt.Errorf("failed to encode the response of a %s call: %w", rec.MethodName, err)
+		}
+
+		f.Calls = append(f.Calls, fixtureCall{
+			Method:   rec.MethodName,
+			Args:     args,
+			Response: res,
+		})
+	}
+
+	content, err := json.MarshalIndent(f, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, content, 0o644)
+}
+
+// Replay loads a fixture saved with SaveRecording, specifying each recorded call response
+// for the recorded arguments, as if WithArgs(...).Returns(...) was called for them.
+//
+// Custom types stored on the fixture must be registered with RegisterType before replaying it.
+// The context arguments are replayed as a matcher of any context, and the arguments that can't be stored
+// as JSON, like channels and functions, are replayed as MatchAny
+func (mock *Mock) Replay(path string) error {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	var f fixture
+	if err := json.Unmarshal(content, &f); err != nil {
+		return fmt.Errorf("failed to decode the fixture %s: %w", path, err)
+	}
+
+	for i, call := range f.Calls {
+		args, err := decodeFixtureValues(call.Args)
+		if err != nil {
+			return fmt.Errorf("failed to decode the arguments of the call %d (%s): %w", i, call.Method, err)
+		}
+
+		res, err := decodeFixtureValues(call.Response)
+		if err != nil {
+			return fmt.Errorf("failed to decode the response of the call %d (%s): %w", i, call.Method, err)
+		}
+
+		mock.Method(call.Method).WithArgs(args...).Returns(res...)
+	}
+
+	return nil
+}
+
+// encodeFixtureArgs encodes the arguments of a recorded call.
+//
+// Unlike the response values, the contexts and the arguments that can't be stored as JSON are stored as a matcher,
+// since a call can still be matched without them. The other arguments must have a registered type
+func encodeFixtureArgs(args []any) ([]fixtureValue, error) {
+	encoded := make([]fixtureValue, len(args))
+	for i, arg := range args {
+		t := reflect.TypeOf(arg)
+		if t != nil && t.Implements(contextType) {
+			encoded[i] = fixtureValue{Type: t.String(), Match: matchContextName}
+			continue
+		}
+		if t != nil && (t.Kind() == reflect.Chan || t.Kind() == reflect.Func || t.Kind() == reflect.UnsafePointer) {
+			encoded[i] = fixtureValue{Type: t.String(), Match: matchAnyName}
+			continue
+		}
+
+		_, isErr := arg.(error)
+		if t != nil && !isErr && !isRegisteredType(t) {
+			return nil, fmt.Errorf("the argument %d has the type %s, that is not registered, use RegisterType to register it", i, t)
+		}
+
+		fv, err := encodeFixtureValue(arg)
+		if err != nil {
+			return nil, fmt.Errorf("the argument %d can't be encoded: %w", i, err)
+		}
+		encoded[i] = fv
+	}
+
+	return encoded, nil
+}
+
+// isRegisteredType returns if the type was registered with RegisterType
+func isRegisteredType(t reflect.Type) bool {
+	registeredTypesMu.RLock()
+	defer registeredTypesMu.RUnlock()
+
+	return registeredTypes[t.String()] == t
+}
+
+func encodeFixtureValues(values []any) ([]fixtureValue, error) {
+	encoded := make([]fixtureValue, len(values))
+	for i, val := range values {
+		fv, err := encodeFixtureValue(val)
+		if err != nil {
+			return nil, err
+		}
+		encoded[i] = fv
+	}
+
+	return encoded, nil
+}
+
+func encodeFixtureValue(val any) (fixtureValue, error) {
+	if val == nil {
+		return fixtureValue{Type: nilTypeName}, nil
+	}
+
+	typeName := reflect.TypeOf(val).String()
+
+	registeredTypesMu.RLock()
+	_, registered := registeredTypes[typeName]
+	registeredTypesMu.RUnlock()
+
+	// errors from unregistered types can only be stored by their message
+	if err, ok := val.(error); ok && !registered {
+		raw, _ := json.Marshal(err.Error())
+		return fixtureValue{Type: errorTypeName, Value: raw}, nil
+	}
+
+	raw, err := json.Marshal(val)
+	if err != nil {
+		return fixtureValue{}, err
+	}
+
+	return fixtureValue{Type: typeName, Value: raw}, nil
+}
+
+func decodeFixtureValues(values []fixtureValue) ([]any, error) {
+	decoded := make([]any, len(values))
+	for i, fv := range values {
+		val, err := decodeFixtureValue(fv)
+		if err != nil {
+			return nil, err
+		}
+		decoded[i] = val
+	}
+
+	return decoded, nil
+}
+
+func decodeFixtureValue(fv fixtureValue) (any, error) {
+	switch fv.Match {
+	case matchAnyName:
+		return MatchAny{}, nil
+	case matchContextName:
+		return MatchType[context.Context]{}, nil
+	}
+
+	switch fv.Type {
+	case nilTypeName:
+		return nil, nil
+	case errorTypeName:
+		var msg string
+		if err := json.Unmarshal(fv.Value, &msg); err != nil {
+			return nil, err
+		}
+
+		return errors.New(msg), nil
+	}
+
+	registeredTypesMu.RLock()
+	t, ok := registeredTypes[fv.Type]
+	registeredTypesMu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("the type %s is not registered, use RegisterType to register it", fv.Type)
+	}
+
+	ptr := reflect.New(t)
+	if err := json.Unmarshal(fv.Value, ptr.Interface()); err != nil {
+		return nil, err
+	}
+
+	return ptr.Elem().Interface(), nil
+}
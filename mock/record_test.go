@@ -0,0 +1,194 @@
+package mock
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type recordUser struct {
+	ID   string
+	Name string
+}
+
+type realUserRepo struct{}
+
+func (r realUserRepo) GetUser(id string) (recordUser, error) {
+	if id == "" {
+		return recordUser{}, errors.New("user not found")
+	}
+
+	return recordUser{ID: id, Name: "User " + id}, nil
+}
+
+func (r realUserRepo) CountUsers() int {
+	return 42
+}
+
+type recordFilter struct {
+	Active bool
+}
+
+type recordQuery struct {
+	Term string
+}
+
+func (r realUserRepo) FindUser(ctx context.Context, name string, filter recordFilter) (recordUser, error) {
+	if !filter.Active {
+		return recordUser{ID: "u0", Name: name}, ctx.Err()
+	}
+
+	return recordUser{ID: "u1", Name: name}, ctx.Err()
+}
+
+func (r realUserRepo) Search(query recordQuery) string {
+	return "found " + query.Term
+}
+
+func (r realUserRepo) CountIDs(ids []string) int {
+	return len(ids)
+}
+
+func (r realUserRepo) Watch(events chan int) int {
+	return 1
+}
+
+func TestRecordAndReplay(t *testing.T) {
+	RegisterType[recordUser]()
+	RegisterType[recordFilter]()
+
+	t.Run("Should record the real implementation calls and replay them", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "fixture.json")
+
+		recorder := NewMock()
+		recorder.Record(realUserRepo{})
+
+		res := recorder.GetResponseAndRegister("GetUser", "u1")
+		assert.Equal(t, MethodResponse{recordUser{ID: "u1", Name: "User u1"}, nil}, res)
+		recorder.GetResponseAndRegister("GetUser", "")
+		recorder.GetResponseAndRegister("CountUsers")
+
+		err := recorder.SaveRecording(path)
+		assert.Nil(t, err)
+
+		replayer := NewMock()
+		err = replayer.Replay(path)
+		assert.Nil(t, err)
+
+		assert.Equal(t, MethodResponse{recordUser{ID: "u1", Name: "User u1"}, nil}, replayer.GetMethodResponse("GetUser", "u1"))
+		assert.Equal(t, MethodResponse{recordUser{}, errors.New("user not found")}, replayer.GetMethodResponse("GetUser", ""))
+		assert.Equal(t, MethodResponse{42}, replayer.GetMethodResponse("CountUsers"))
+		assert.Empty(t, replayer.GetMethodResponse("GetUser", "u2"))
+	})
+	t.Run("Should replay the context arguments and the arguments that can't be stored as matchers", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "fixture.json")
+
+		recorder := NewMock()
+		recorder.Record(realUserRepo{})
+		recorder.GetResponseAndRegister("FindUser", context.Background(), "John", recordFilter{Active: true})
+		recorder.GetResponseAndRegister("FindUser", context.Background(), "John", recordFilter{Active: false})
+		recorder.GetResponseAndRegister("Watch", make(chan int))
+
+		err := recorder.SaveRecording(path)
+		assert.Nil(t, err)
+
+		content, _ := os.ReadFile(path)
+		assert.Contains(t, string(content), `"type": "context.backgroundCtx",
+          "match": "context"`)
+		assert.Contains(t, string(content), `"type": "chan int",
+          "match": "any"`)
+
+		replayer := NewMock()
+		err = replayer.Replay(path)
+		assert.Nil(t, err)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		assert.Equal(t, MethodResponse{recordUser{ID: "u1", Name: "John"}, nil}, replayer.GetMethodResponse("FindUser", ctx, "John", recordFilter{Active: true}))
+		assert.Equal(t, MethodResponse{recordUser{ID: "u0", Name: "John"}, nil}, replayer.GetMethodResponse("FindUser", ctx, "John", recordFilter{}))
+		assert.Empty(t, replayer.GetMethodResponse("FindUser", ctx, "Jane", recordFilter{}))
+		assert.Empty(t, replayer.GetMethodResponse("FindUser", "not a context", "John", recordFilter{}))
+		assert.Equal(t, MethodResponse{1}, replayer.GetMethodResponse("Watch", make(chan int)))
+	})
+	t.Run("Should fail to save the arguments of unregistered types", func(t *testing.T) {
+		recorder := NewMock()
+		recorder.Record(realUserRepo{})
+		recorder.GetResponseAndRegister("Search", recordQuery{Term: "a"})
+		recorder.GetResponseAndRegister("Search", recordQuery{Term: "b"})
+
+		err := recorder.SaveRecording(filepath.Join(t.TempDir(), "fixture.json"))
+
+		assert.EqualError(t, err, "failed to encode the arguments of a Search call: the argument 0 has the type mock.recordQuery, that is not registered, use RegisterType to register it")
+	})
+	t.Run("Should save the args as they were when the method was called", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "fixture.json")
+
+		recorder := NewMock()
+		recorder.Record(realUserRepo{})
+		ids := []string{"u1"}
+		recorder.GetResponseAndRegister("CountIDs", ids)
+		ids[0] = "u2"
+
+		err := recorder.SaveRecording(path)
+		assert.Nil(t, err)
+
+		replayer := NewMock()
+		err = replayer.Replay(path)
+		assert.Nil(t, err)
+
+		assert.Equal(t, MethodResponse{1}, replayer.GetMethodResponse("CountIDs", []string{"u1"}))
+		assert.Empty(t, replayer.GetMethodResponse("CountIDs", []string{"u2"}))
+	})
+	t.Run("Should not record the calls that have a specified response", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "fixture.json")
+
+		recorder := NewMock()
+		recorder.Record(realUserRepo{})
+		recorder.Method("CountUsers").SetResponse(10)
+
+		recorder.GetResponseAndRegister("CountUsers")
+
+		err := recorder.SaveRecording(path)
+		assert.Nil(t, err)
+
+		content, _ := os.ReadFile(path)
+		assert.JSONEq(t, `{"calls": []}`, string(content))
+	})
+	t.Run("Should fail to replay unregistered types", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "fixture.json")
+		content := `{"calls": [{"method": "GetUser", "args": [{"type": "mock.unknownType", "value": {}}], "response": []}]}`
+		_ = os.WriteFile(path, []byte(content), 0o644)
+
+		m := NewMock()
+		err := m.Replay(path)
+
+		assert.EqualError(t, err, "failed to decode the arguments of the call 0 (GetUser): the type mock.unknownType is not registered, use RegisterType to register it")
+	})
+	t.Run("Should fail to replay a fixture that does not exist", func(t *testing.T) {
+		m := NewMock()
+		err := m.Replay(filepath.Join(t.TempDir(), "missing.json"))
+
+		assert.ErrorIs(t, err, os.ErrNotExist)
+	})
+	t.Run("Should fail to save values that can't be encoded", func(t *testing.T) {
+		recorder := NewMock()
+		recorder.Record(struct{}{})
+		recorder.Method("MakeChan").CallThrough(func() chan int { return make(chan int) })
+
+		recorder.GetResponseAndRegister("MakeChan")
+
+		err := recorder.SaveRecording(filepath.Join(t.TempDir(), "fixture.json"))
+		assert.NotNil(t, err)
+	})
+	t.Run("Should panic if the real implementation is nil", func(t *testing.T) {
+		m := NewMock()
+
+		assert.Panics(t, func() {
+			m.Record(nil)
+		})
+	})
+}
@@ -0,0 +1,130 @@
+package mock
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+)
+
+// renderValue renders a value in a deterministic way, so it can be used on golden files.
+//
+// Unlike the fmt package, pointers are rendered by the value they point to instead of their address.
+// The errors and the fmt.Stringer values are rendered by their string form, instead of their internals
+func renderValue(val any) string {
+	return renderReflectValue(reflect.ValueOf(val), map[uintptr]bool{})
+}
+
+// renderString renders the string form of the errors and the fmt.Stringer values
+func renderString(v reflect.Value) (string, bool) {
+	if !v.CanInterface() {
+		return "", false
+	}
+
+	switch val := v.Interface().(type) {
+	case error:
+		return fmt.Sprintf("%s(%q)", v.Type(), val.Error()), true
+	case time.Time:
+		// the monotonic clock reading changes on every run, so it's stripped
+		return fmt.Sprintf("%s(%q)", v.Type(), val.Round(0).String()), true
+	case fmt.Stringer:
+		return fmt.Sprintf("%s(%q)", v.Type(), val.String()), true
+	default:
+		return "", false
+	}
+}
+
+func renderReflectValue(v reflect.Value, visited map[uintptr]bool) string {
+	if !v.IsValid() {
+		return "nil"
+	}
+
+	if v.Kind() != reflect.Pointer && v.Kind() != reflect.Interface {
+		if s, ok := renderString(v); ok {
+			return s
+		}
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		return fmt.Sprintf("%q", v.String())
+	case reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64, reflect.Complex64, reflect.Complex128:
+		return fmt.Sprint(scalarValue(v))
+	case reflect.Pointer:
+		if v.IsNil() {
+			return fmt.Sprintf("(%s)(nil)", v.Type())
+		}
+		if visited[v.Pointer()] {
+			return fmt.Sprintf("(%s)(<cycle>)", v.Type())
+		}
+		visited[v.Pointer()] = true
+		defer delete(visited, v.Pointer())
+
+		if s, ok := renderString(v); ok {
+			return s
+		}
+
+		return "&" + renderReflectValue(v.Elem(), visited)
+	case reflect.Interface:
+		if v.IsNil() {
+			return "nil"
+		}
+
+		return renderReflectValue(v.Elem(), visited)
+	case reflect.Struct:
+		v = addressable(v)
+		fields := make([]string, v.NumField())
+		for i := range fields {
+			fields[i] = fmt.Sprintf("%s: %s", v.Type().Field(i).Name, renderReflectValue(exportedField(v, i), visited))
+		}
+
+		return fmt.Sprintf("%s{%s}", v.Type(), strings.Join(fields, ", "))
+	case reflect.Slice, reflect.Array:
+		if v.Kind() == reflect.Slice && v.IsNil() {
+			return fmt.Sprintf("%s(nil)", v.Type())
+		}
+
+		items := make([]string, v.Len())
+		for i := range items {
+			items[i] = renderReflectValue(v.Index(i), visited)
+		}
+
+		return fmt.Sprintf("%s{%s}", v.Type(), strings.Join(items, ", "))
+	case reflect.Map:
+		if v.IsNil() {
+			return fmt.Sprintf("%s(nil)", v.Type())
+		}
+
+		items := make([]string, 0, v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			items = append(items, fmt.Sprintf("%s: %s", renderReflectValue(iter.Key(), visited), renderReflectValue(iter.Value(), visited)))
+		}
+		sort.Strings(items)
+
+		return fmt.Sprintf("%s{%s}", v.Type(), strings.Join(items, ", "))
+	default:
+		// functions, channels and unsafe pointers have no deterministic representation
+		return v.Type().String()
+	}
+}
+
+// scalarValue returns the value of a scalar kind, even if it comes from an unexported struct field
+func scalarValue(v reflect.Value) any {
+	switch v.Kind() {
+	case reflect.Bool:
+		return v.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return v.Uint()
+	case reflect.Float32, reflect.Float64:
+		return v.Float()
+	default:
+		return v.Complex()
+	}
+}
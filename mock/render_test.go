@@ -0,0 +1,65 @@
+package mock
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type renderUser struct {
+	Name    string
+	age     int
+	Friends []*renderUser
+}
+
+func TestRenderValue(t *testing.T) {
+	t.Run("Should render scalar values", func(t *testing.T) {
+		assert.Equal(t, `"value"`, renderValue("value"))
+		assert.Equal(t, "42", renderValue(42))
+		assert.Equal(t, "4.2", renderValue(4.2))
+		assert.Equal(t, "true", renderValue(true))
+		assert.Equal(t, "nil", renderValue(nil))
+	})
+	t.Run("Should render pointers by the value they point to", func(t *testing.T) {
+		u := &renderUser{Name: "John", age: 30}
+
+		assert.Equal(t, `&mock.renderUser{Name: "John", age: 30, Friends: []*mock.renderUser(nil)}`, renderValue(u))
+		assert.Equal(t, "(*mock.renderUser)(nil)", renderValue((*renderUser)(nil)))
+	})
+	t.Run("Should not loop on cyclic values", func(t *testing.T) {
+		u := &renderUser{Name: "John"}
+		u.Friends = []*renderUser{u}
+
+		assert.Equal(t, `&mock.renderUser{Name: "John", age: 0, Friends: []*mock.renderUser{(*mock.renderUser)(<cycle>)}}`, renderValue(u))
+	})
+	t.Run("Should render maps sorted by key", func(t *testing.T) {
+		m := map[string]int{"c": 3, "a": 1, "b": 2}
+
+		assert.Equal(t, `map[string]int{"a": 1, "b": 2, "c": 3}`, renderValue(m))
+	})
+	t.Run("Should render slices and arrays", func(t *testing.T) {
+		assert.Equal(t, `[]string{"a", "b"}`, renderValue([]string{"a", "b"}))
+		assert.Equal(t, "[2]int{1, 2}", renderValue([2]int{1, 2}))
+	})
+	t.Run("Should render errors by their message", func(t *testing.T) {
+		assert.Equal(t, `*errors.errorString("mock error")`, renderValue(errors.New("mock error")))
+	})
+	t.Run("Should render times and stringers by their string form", func(t *testing.T) {
+		loc := time.FixedZone("BRT", -3*60*60)
+		at := time.Date(2024, 1, 2, 3, 4, 5, 0, loc)
+		withMonotonic := time.Now()
+
+		assert.Equal(t, `time.Time("2024-01-02 03:04:05 -0300 BRT")`, renderValue(at))
+		assert.Equal(t, `*time.Time("2024-01-02 03:04:05 -0300 BRT")`, renderValue(&at))
+		assert.Equal(t, `mock.event{Name: "e1", at: time.Time("2024-01-02 03:04:05 -0300 BRT")}`, renderValue(event{Name: "e1", at: at}))
+		assert.Equal(t, `*big.Int("42")`, renderValue(big.NewInt(42)))
+		assert.NotContains(t, renderValue(withMonotonic), "m=")
+	})
+	t.Run("Should render functions and channels by their type", func(t *testing.T) {
+		assert.Equal(t, "func(int) string", renderValue(func(int) string { return "" }))
+		assert.Equal(t, "chan int", renderValue(make(chan int)))
+	})
+}
@@ -0,0 +1,165 @@
+package mock
+
+import (
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"strings"
+)
+
+// Signatures returns the method signatures of the type I, mapped by the method name.
+//
+// I can be an interface, in which case every interface method is considered,
+// or a function set struct (see For), in which case every exported function field is considered.
+//
+// Use it with SetSignatures to make the mock validate its responses against the method signatures:
+//
+//	m := mock.NewMock()
+//	m.SetSignatures(mock.Signatures[MyDBInterface]())
+//
+// This function panics if I is not an interface or a struct.
+func Signatures[I any]() map[string]reflect.Type {
+	t := reflect.TypeOf((*I)(nil)).Elem()
+	signatures := make(map[string]reflect.Type)
+
+	switch t.Kind() {
+	case reflect.Interface:
+		for i := 0; i < t.NumMethod(); i++ {
+			m := t.Method(i)
+			signatures[m.Name] = m.Type
+		}
+	case reflect.Struct:
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			if !field.IsExported() || field.Type.Kind() != reflect.Func {
+				continue
+			}
+
+			name := field.Name
+			if tag, ok := field.Tag.Lookup("mock"); ok && tag != "" {
+				name = tag
+			}
+			signatures[name] = field.Type
+		}
+	default:
+		msg := fmt.Sprintf("Tried to get the method signatures of the type %s, but only interfaces and structs with function fields are supported", t)
+		panic(msg)
+	}
+
+	return signatures
+}
+
+// checkResponse checks if a response matches a method signature,
+// returning a description of the problem if it does not
+func checkResponse(signature reflect.Type, response []any) string {
+	if len(response) != signature.NumOut() {
+		return fmt.Sprintf("the method returns %d values, and %d response values were specified", signature.NumOut(), len(response))
+	}
+
+	for i, val := range response {
+		out := signature.Out(i)
+		if val == nil {
+			if !isNillable(out) {
+				return fmt.Sprintf("the response value on the index %d was nil, and the method returns a %s on that index", i, out)
+			}
+			continue
+		}
+
+		if !reflect.TypeOf(val).AssignableTo(out) {
+			return fmt.Sprintf("the response value on the index %d was a %T, and the method returns a %s on that index", i, val, out)
+		}
+	}
+
+	return ""
+}
+
+// checkArgs checks if the args match a method signature,
+// returning a description of the problem if they do not.
+//
+// Argument matchers are considered valid for any argument,
+// and the variadic arguments can be either spread or passed as a single slice
+func checkArgs(signature reflect.Type, args []any) string {
+	numIn := signature.NumIn()
+	if signature.IsVariadic() {
+		if len(args) < numIn-1 {
+			return fmt.Sprintf("the method receives at least %d arguments, and %d arguments were specified", numIn-1, len(args))
+		}
+	} else if len(args) != numIn {
+		return fmt.Sprintf("the method receives %d arguments, and %d arguments were specified", numIn, len(args))
+	}
+
+	for i, arg := range args {
+		if _, ok := arg.(ArgumentMatcher); ok {
+			continue
+		}
+
+		in := argumentType(signature, i)
+		if signature.IsVariadic() && i == numIn-1 && len(args) == numIn && reflect.TypeOf(arg) == signature.In(i) {
+			continue
+		}
+
+		if arg == nil {
+			if !isNillable(in) {
+				return fmt.Sprintf("the argument %d was nil, and the method receives a %s on that argument", i, in)
+			}
+			continue
+		}
+
+		if !reflect.TypeOf(arg).AssignableTo(in) {
+			return fmt.Sprintf("the argument %d was a %T, and the method receives a %s on that argument", i, arg, in)
+		}
+	}
+
+	return ""
+}
+
+// isNillable returns if nil is a valid value for the type t
+func isNillable(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Chan, reflect.Func, reflect.Interface, reflect.Map, reflect.Pointer, reflect.Slice:
+		return true
+	default:
+		return false
+	}
+}
+
+// callerLocation returns the file:line of the first caller outside this package,
+// which is usually the test line that is setting up the mock
+func callerLocation() string {
+	pkgPath := reflect.TypeOf(Mock{}).PkgPath()
+
+	pcs := make([]uintptr, 32)
+	n := runtime.Callers(2, pcs)
+	frames := runtime.CallersFrames(pcs[:n])
+	for {
+		frame, more := frames.Next()
+		if !strings.HasPrefix(frame.Function, pkgPath+".") || strings.HasSuffix(frame.File, "_test.go") {
+			return fmt.Sprintf("%s:%d", filepath.Base(frame.File), frame.Line)
+		}
+
+		if !more {
+			return "unknown location"
+		}
+	}
+}
+
+// describeArg returns how the argument 'i' of a method should be referred to on messages,
+// including its name and type when they were declared
+func (mock *Mock) describeArg(methodName string, i int) string {
+	desc := []string{}
+	if names := mock.state().argNames[methodName]; i < len(names) {
+		desc = append(desc, names[i])
+	}
+
+	signature, ok := mock.state().signatures[methodName]
+	if ok && (i < signature.NumIn() || signature.IsVariadic()) {
+		desc = append(desc, argumentType(signature, i).String())
+	}
+
+	if len(desc) == 0 {
+		return fmt.Sprintf("argument %d", i)
+	}
+
+	return fmt.Sprintf("argument %d (%s)", i, strings.Join(desc, " "))
+}
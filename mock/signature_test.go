@@ -0,0 +1,235 @@
+package mock
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type userRepo interface {
+	GetUserCount(userID string) (int, error)
+	DeleteUser(userID string) error
+}
+
+func TestSignatures(t *testing.T) {
+	t.Run("Should return the signatures of an interface", func(t *testing.T) {
+		signatures := Signatures[userRepo]()
+
+		assert.Equal(t, map[string]reflect.Type{
+			"GetUserCount": reflect.TypeOf((func(string) (int, error))(nil)),
+			"DeleteUser":   reflect.TypeOf((func(string) error)(nil)),
+		}, signatures)
+	})
+	t.Run("Should return the signatures of a function set", func(t *testing.T) {
+		signatures := Signatures[userRepoFuncs]()
+
+		assert.Equal(t, map[string]reflect.Type{
+			"GetUserCount": reflect.TypeOf((func(string) (int, error))(nil)),
+			"Delete":       reflect.TypeOf((func(string) error)(nil)),
+		}, signatures)
+	})
+	t.Run("Should panic if the type is not an interface or a struct", func(t *testing.T) {
+		assert.PanicsWithValue(t,
+			"Tried to get the method signatures of the type int, but only interfaces and structs with function fields are supported",
+			func() {
+				Signatures[int]()
+			},
+		)
+	})
+}
+
+func TestResponseValidation(t *testing.T) {
+	t.Run("Should accept responses that match the signature", func(t *testing.T) {
+		m := NewMock()
+		m.SetSignatures(Signatures[userRepo]())
+
+		m.Method("GetUserCount").SetResponse(5, nil)
+		m.Method("GetUserCount").WithArgs("userID").Returns(0, errors.New("mock error"))
+		m.Method("DeleteUser").SetResponse(nil)
+		m.SetMethodResponse("SomeUndeclaredMethod", "anything")
+
+		assert.Equal(t, MethodResponse{5, nil}, m.GetMethodResponse("GetUserCount"))
+	})
+	t.Run("Should panic with the setup location if the response length is wrong", func(t *testing.T) {
+		m := NewMock()
+		m.SetSignatures(Signatures[userRepo]())
+
+		_, _, line, _ := runtime.Caller(0)
+		assert.PanicsWithValue(t,
+			fmt.Sprintf("Tried to set a response for the mock method GetUserCount at signature_test.go:%d, but the method returns 2 values, and 1 response values were specified", line+4),
+			func() {
+				m.Method("GetUserCount").SetResponse(5)
+			},
+		)
+	})
+	t.Run("Should panic if a response value has the wrong type", func(t *testing.T) {
+		m := NewMock()
+		m.SetSignatures(Signatures[userRepo]())
+
+		_, _, line, _ := runtime.Caller(0)
+		assert.PanicsWithValue(t,
+			fmt.Sprintf("Tried to set a response for the mock method GetUserCount at signature_test.go:%d, but the response value on the index 0 was a string, and the method returns a int on that index", line+4),
+			func() {
+				m.Method("GetUserCount").WithArgs("userID").Returns("5", nil)
+			},
+		)
+		_, _, line, _ = runtime.Caller(0)
+		assert.PanicsWithValue(t,
+			fmt.Sprintf("Tried to set a response for the mock method GetUserCount at signature_test.go:%d, but the response value on the index 0 was nil, and the method returns a int on that index", line+4),
+			func() {
+				m.SetMethodResponse("GetUserCount", nil, nil)
+			},
+		)
+	})
+}
+
+func TestSetSignature(t *testing.T) {
+	t.Run("Should declare the method signature from a function value", func(t *testing.T) {
+		m := NewMock()
+		m.Method("GetUserCount").SetSignature((func(string) (int, error))(nil))
+
+		assert.Panics(t, func() {
+			m.Method("GetUserCount").SetResponse("5", nil)
+		})
+	})
+	t.Run("Should declare the method signature from a reflect type", func(t *testing.T) {
+		m := NewMock()
+		m.Method("DeleteUser").SetSignature(reflect.TypeOf((func(string) error)(nil)))
+
+		assert.Panics(t, func() {
+			m.Method("DeleteUser").SetResponse(1)
+		})
+	})
+	t.Run("Should panic if the signature is not a function", func(t *testing.T) {
+		m := NewMock()
+
+		assert.PanicsWithValue(t,
+			"Tried to set a signature for the mock method MyMethod, but the value was not a function",
+			func() {
+				m.Method("MyMethod").SetSignature(42)
+			},
+		)
+	})
+}
+
+func TestNewMockFor(t *testing.T) {
+	t.Run("Should accept the interface methods", func(t *testing.T) {
+		m := NewMockFor[userRepo]()
+
+		m.Method("GetUserCount").WithArgs("userID").Returns(5, nil)
+		m.SetMethodResponse("DeleteUser", nil)
+		res := m.GetResponseAndRegister("GetUserCount", "userID")
+
+		assert.Equal(t, MethodResponse{5, nil}, res)
+		assert.True(t, m.Method("GetUserCount").CalledOnce())
+	})
+	t.Run("Should panic suggesting the closest method name if the method is unknown", func(t *testing.T) {
+		m := NewMockFor[userRepo]()
+
+		_, _, line, _ := runtime.Caller(0)
+		assert.PanicsWithValue(t,
+			fmt.Sprintf("Tried to register a call for the unknown method GetUsrCount on a mock for mock.userRepo at signature_test.go:%d. Did you mean GetUserCount?", line+4),
+			func() {
+				m.RegisterMethodCall("GetUsrCount", "userID")
+			},
+		)
+		assert.Panics(t, func() {
+			m.Method("deleteuser")
+		})
+		assert.Panics(t, func() {
+			m.SetMethodResponse("GetUserCont", 5, nil)
+		})
+		assert.Panics(t, func() {
+			method := Method{name: "DeletUser", mock: &m}
+			method.WithArgs("userID")
+		})
+	})
+	t.Run("Should not suggest a method name if none is close", func(t *testing.T) {
+		m := NewMockFor[userRepo]()
+
+		_, _, line, _ := runtime.Caller(0)
+		assert.PanicsWithValue(t,
+			fmt.Sprintf("Tried to get the unknown method Save on a mock for mock.userRepo at signature_test.go:%d", line+4),
+			func() {
+				m.Method("Save")
+			},
+		)
+	})
+	t.Run("Should panic if the args do not match the method signature", func(t *testing.T) {
+		m := NewMockFor[userRepo]()
+
+		_, _, line, _ := runtime.Caller(0)
+		assert.PanicsWithValue(t,
+			fmt.Sprintf("Tried to register a call for the mock method GetUserCount at signature_test.go:%d, but the method receives 1 arguments, and 2 arguments were specified", line+4),
+			func() {
+				m.RegisterMethodCall("GetUserCount", "userID", 42)
+			},
+		)
+		_, _, line, _ = runtime.Caller(0)
+		assert.PanicsWithValue(t,
+			fmt.Sprintf("Tried to set args for the mock method DeleteUser at signature_test.go:%d, but the argument 0 was a int, and the method receives a string on that argument", line+4),
+			func() {
+				m.Method("DeleteUser").WithArgs(42)
+			},
+		)
+	})
+	t.Run("Should accept argument matchers", func(t *testing.T) {
+		m := NewMockFor[userRepo]()
+
+		m.Method("DeleteUser").WithArgs(MatchAny{}).Returns(nil)
+	})
+	t.Run("Should keep the binding after a reset", func(t *testing.T) {
+		m := NewMockFor[userRepo]()
+
+		m.Reset()
+
+		assert.Panics(t, func() {
+			m.Method("GetUsrCount")
+		})
+		assert.Panics(t, func() {
+			m.Method("GetUserCount").SetResponse("5", nil)
+		})
+	})
+}
+
+func TestDescribeArg(t *testing.T) {
+	t.Run("Should describe the argument with the declared name and type", func(t *testing.T) {
+		m := NewMock()
+		m.Method("Find").SetSignature((func(string, ...int) error)(nil))
+		m.Method("Find").SetArgNames("userID")
+		m.Method("Get").SetArgNames("ctx", "userID")
+
+		assert.Equal(t, "argument 0 (userID string)", m.describeArg("Find", 0))
+		assert.Equal(t, "argument 3 (int)", m.describeArg("Find", 3))
+		assert.Equal(t, "argument 1 (userID)", m.describeArg("Get", 1))
+		assert.Equal(t, "argument 2", m.describeArg("Get", 2))
+		assert.Equal(t, "argument 0", m.describeArg("Other", 0))
+	})
+	t.Run("Should keep the argument names on reset", func(t *testing.T) {
+		m := NewMock()
+		m.Method("Get").SetArgNames("ctx", "userID")
+		m.Reset()
+
+		assert.Equal(t, "argument 1 (userID)", m.describeArg("Get", 1))
+	})
+}
+
+func TestCheckArgs(t *testing.T) {
+	variadic := reflect.TypeOf((func(string, ...int))(nil))
+
+	t.Run("Should accept spread variadic arguments", func(t *testing.T) {
+		assert.Empty(t, checkArgs(variadic, []any{"a"}))
+		assert.Empty(t, checkArgs(variadic, []any{"a", 1, 2, 3}))
+	})
+	t.Run("Should accept the variadic arguments as a slice", func(t *testing.T) {
+		assert.Empty(t, checkArgs(variadic, []any{"a", []int{1, 2}}))
+	})
+	t.Run("Should reject invalid variadic arguments", func(t *testing.T) {
+		assert.Equal(t, "the method receives at least 1 arguments, and 0 arguments were specified", checkArgs(variadic, []any{}))
+		assert.Equal(t, "the argument 2 was a string, and the method receives a int on that argument", checkArgs(variadic, []any{"a", 1, "2"}))
+	})
+}
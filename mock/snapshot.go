@@ -0,0 +1,124 @@
+package mock
+
+import "reflect"
+
+// MockSnapshot represents an immutable copy of the mock responses and calls, see Snapshot
+type MockSnapshot struct {
+	responses    map[string]MethodResponse
+	stubs        map[string]*Stub
+	callThroughs map[string]reflect.Value
+	calls        []MockCall
+	callSeqs     []int
+	verified     map[int]bool
+	matched      map[int]bool
+}
+
+// Snapshot returns an immutable copy of the mock responses and calls,
+// that can be used to restore the mock to its current state
+func (mock *Mock) Snapshot() MockSnapshot {
+	return MockSnapshot{
+		responses:    copyResponses(mock.state().responses),
+		stubs:        copyStubs(mock.state().stubs),
+		callThroughs: copyCallThroughs(mock.state().callThroughs),
+		calls:        append([]MockCall{}, mock.state().calls...),
+		callSeqs:     append([]int{}, mock.state().callSeqs...),
+		verified:     copySeqSet(mock.state().verified),
+		matched:      copySeqSet(mock.state().matched),
+	}
+}
+
+// Restore restores the mock responses and calls to the state they had when the snapshot was taken
+func (mock *Mock) Restore(s MockSnapshot) {
+	mock.state().responses = copyResponses(s.responses)
+	mock.state().stubs = copyStubs(s.stubs)
+	mock.state().callThroughs = copyCallThroughs(s.callThroughs)
+	mock.state().calls = append([]MockCall{}, s.calls...)
+	mock.state().callSeqs = append([]int{}, s.callSeqs...)
+	mock.state().verified = copySeqSet(s.verified)
+	mock.state().matched = copySeqSet(s.matched)
+}
+
+// Clone returns an independent mock, with the same responses specified on this mock, but without any calls.
+//
+// It's useful to share a set of responses between parallel tests, since each clone has its own state,
+// with deep copies of the response values, so a test can change a returned slice or map without affecting the others.
+//
+// The declared signatures and argument names are copied too, but the methods that forbid argument mutation are not,
+// since the mutation check belongs to the test that called ForbidArgMutation. Call it on the clone when needed
+func (mock *Mock) Clone() Mock {
+	s := mock.state()
+
+	clone := newMockState(s.name)
+	clone.responses = make(map[string]MethodResponse, len(s.responses))
+	for key, res := range s.responses {
+		clone.responses[key] = copyValues(res)
+	}
+	clone.callThroughs = copyCallThroughs(s.callThroughs)
+	clone.signatures = copySignatures(s.signatures)
+	clone.argNames = copyArgNames(s.argNames)
+	clone.boundTo = s.boundTo
+	clone.equality = s.equality.copy()
+
+	for key, stub := range copyStubs(s.stubs) {
+		stub.hits = 0
+		stub.Response = copyValues(stub.Response)
+		clone.stubs[key] = stub
+	}
+
+	return Mock{s: clone}
+}
+
+func copyResponses(responses map[string]MethodResponse) map[string]MethodResponse {
+	c := make(map[string]MethodResponse, len(responses))
+	for key, res := range responses {
+		c[key] = res
+	}
+
+	return c
+}
+
+func copyStubs(stubs map[string]*Stub) map[string]*Stub {
+	c := make(map[string]*Stub, len(stubs))
+	for key, s := range stubs {
+		copied := *s
+		c[key] = &copied
+	}
+
+	return c
+}
+
+func copyCallThroughs(callThroughs map[string]reflect.Value) map[string]reflect.Value {
+	c := make(map[string]reflect.Value, len(callThroughs))
+	for key, fn := range callThroughs {
+		c[key] = fn
+	}
+
+	return c
+}
+
+func copySeqSet(seqs map[int]bool) map[int]bool {
+	c := make(map[int]bool, len(seqs))
+	for seq, v := range seqs {
+		c[seq] = v
+	}
+
+	return c
+}
+
+func copySignatures(signatures map[string]reflect.Type) map[string]reflect.Type {
+	c := make(map[string]reflect.Type, len(signatures))
+	for name, signature := range signatures {
+		c[name] = signature
+	}
+
+	return c
+}
+
+func copyArgNames(argNames map[string][]string) map[string][]string {
+	c := make(map[string][]string, len(argNames))
+	for name, names := range argNames {
+		c[name] = append([]string{}, names...)
+	}
+
+	return c
+}
@@ -0,0 +1,125 @@
+package mock
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSnapshot(t *testing.T) {
+	t.Run("Should restore the mock to the state it had when the snapshot was taken", func(t *testing.T) {
+		m := NewMock()
+		m.Method("Get").SetResponse("user")
+		m.Method("Get").WithArgs("u1").Returns("user 1")
+		m.RegisterMethodCall("Get", "u1")
+
+		s := m.Snapshot()
+
+		m.Method("Get").SetResponse("another user")
+		m.Method("Save").SetResponse(nil)
+		m.Method("Save").CallThrough(func() {})
+		m.RegisterMethodCall("Save", "u1")
+
+		m.Restore(s)
+
+		assert.Equal(t, MethodResponse{"user"}, m.GetMethodResponse("Get"))
+		assert.Equal(t, MethodResponse{"user 1"}, m.GetMethodResponse("Get", "u1"))
+		assert.Empty(t, m.GetMethodResponse("Save"))
+		assert.Empty(t, m.state().callThroughs)
+		assert.Equal(t, []MockCall{{MethodName: "Get", Args: []any{"u1"}}}, m.state().calls)
+	})
+	t.Run("Should not be affected by the mock changes after it's restored", func(t *testing.T) {
+		m := NewMock()
+		m.Method("Get").SetResponse("user")
+
+		s := m.Snapshot()
+		m.Restore(s)
+
+		m.Method("Get").SetResponse("another user")
+		m.RegisterMethodCall("Get", "u1")
+		m.GetMethodResponse("Get")
+
+		assert.Equal(t, MethodResponse{"user"}, s.responses["Get"])
+		assert.Equal(t, 0, s.stubs["Get"].hits)
+		assert.Empty(t, s.calls)
+	})
+}
+
+func TestMockSnapshot(t *testing.T) {
+	t.Run("Should be able to store the snapshot", func(t *testing.T) {
+		m := NewMock()
+		m.Method("Get").SetResponse("user")
+		suite := struct {
+			snapshot MockSnapshot
+		}{snapshot: m.Snapshot()}
+
+		m.Method("Get").SetResponse("other user")
+		m.Restore(suite.snapshot)
+
+		assert.Equal(t, "user", m.GetMethodResponse("Get").GetString(0))
+	})
+}
+
+func TestClone(t *testing.T) {
+	t.Run("Should return an independent mock with the same responses", func(t *testing.T) {
+		m := NewMockFor[userRepo]()
+		m.Method("GetUserCount").SetResponse(5, nil)
+		m.Method("GetUserCount").WithArgs("u1").Returns(1, nil)
+		m.GetResponseAndRegister("GetUserCount", "u2")
+
+		clone := m.Clone()
+
+		assert.Empty(t, clone.state().calls)
+		assert.Equal(t, MethodResponse{5, nil}, clone.GetMethodResponse("GetUserCount"))
+		assert.Equal(t, MethodResponse{1, nil}, clone.GetMethodResponse("GetUserCount", "u1"))
+		assert.Panics(t, func() {
+			clone.Method("GetUsrCount")
+		})
+
+		clone.Method("GetUserCount").SetResponse(10, nil)
+		clone.RegisterMethodCall("GetUserCount", "u3")
+
+		assert.Equal(t, MethodResponse{5, nil}, m.GetMethodResponse("GetUserCount"))
+		assert.True(t, m.CalledOnce())
+	})
+	t.Run("Should not share the signatures and the argument names with the original mock", func(t *testing.T) {
+		m := NewMock()
+		m.Method("Get").SetSignature((func(string) error)(nil))
+		m.Method("Get").SetArgNames("userID")
+		m.Method("Save").ForbidArgMutation(t)
+
+		clone := m.Clone()
+		clone.Method("Get").SetArgNames("id")
+		clone.Method("Delete").SetSignature((func(string) error)(nil))
+		clone.RegisterMethodCall("Save", []string{"u1"})
+
+		assert.Equal(t, "argument 0 (userID string)", m.describeArg("Get", 0))
+		assert.Equal(t, "argument 0 (id string)", clone.describeArg("Get", 0))
+		assert.NotContains(t, m.state().signatures, "Delete")
+		assert.Empty(t, clone.state().watchedCalls)
+	})
+	t.Run("Should not share the response values with the original mock", func(t *testing.T) {
+		m := NewMock()
+		m.Method("List").SetResponse([]string{"u1"}, map[string]int{"u1": 1})
+		m.Method("List").WithArgs("active").Returns([]string{"u2"})
+
+		c1, c2 := m.Clone(), m.Clone()
+		c1.GetMethodResponse("List")[0].([]string)[0] = "changed"
+		c1.GetMethodResponse("List")[1].(map[string]int)["u1"] = 2
+		c1.GetMethodResponse("List", "active")[0].([]string)[0] = "changed"
+
+		assert.Equal(t, MethodResponse{[]string{"u1"}, map[string]int{"u1": 1}}, c2.GetMethodResponse("List"))
+		assert.Equal(t, MethodResponse{[]string{"u1"}, map[string]int{"u1": 1}}, m.GetMethodResponse("List"))
+		assert.Equal(t, MethodResponse{[]string{"u2"}}, m.GetMethodResponse("List", "active"))
+	})
+	t.Run("Should not copy the stubs usage", func(t *testing.T) {
+		m := NewMock()
+		m.Method("Get").SetResponse("user")
+		m.GetMethodResponse("Get")
+
+		clone := m.Clone()
+
+		assert.Empty(t, m.UnusedStubs())
+		assert.Equal(t, 1, len(clone.UnusedStubs()))
+	})
+}
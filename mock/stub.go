@@ -0,0 +1,194 @@
+package mock
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Stub represents a response specified for a mock method
+type Stub struct {
+	MethodName string
+	// Args are the arguments the response was specified for,
+	// it's nil when the response is the method default response
+	Args     []any
+	Response MethodResponse
+
+	hits  int
+	order int
+	// origin is the location where the stub was specified
+	origin string
+	// isolated indicates if the response values should be deep copied on every call
+	isolated bool
+	// lazy builds the response values on every call, when specified
+	lazy func() []any
+	// argAt is the position of the only argument in Args, when the response was specified with WithArgAt
+	argAt *int
+}
+
+// hasResponse returns if the stub has a response to return
+func (s *Stub) hasResponse() bool {
+	return s.lazy != nil || !s.Response.IsEmpty()
+}
+
+// response returns the response values for a call
+func (s *Stub) response() MethodResponse {
+	if s.lazy != nil {
+		return s.lazy()
+	}
+	if s.isolated {
+		return copyValues(s.Response)
+	}
+
+	return s.Response
+}
+
+// String returns a readable representation of the stub
+func (s Stub) String() string {
+	response := formatArgs(s.Response)
+	if s.lazy != nil {
+		response = "(lazy response)"
+	}
+
+	if s.Args == nil {
+		return fmt.Sprintf("%s (any arguments) -> %s", s.MethodName, response)
+	}
+	if s.argAt != nil {
+		return fmt.Sprintf("%s(argument %d: %s) -> %s", s.MethodName, *s.argAt, formatArgs(s.Args), response)
+	}
+
+	return fmt.Sprintf("%s(%s) -> %s", s.MethodName, formatArgs(s.Args), response)
+}
+
+// usesMatchers returns if the stub args are not compared as a whole,
+// either because they have argument matchers, or because the stub is for a single argument
+func (s *Stub) usesMatchers() bool {
+	return s.argAt != nil || hasMatcher(s.Args)
+}
+
+// matches returns if the stub args match the call args
+func (s *Stub) matches(eq *equality, call MockCall) bool {
+	if s.argAt != nil {
+		return calledWithArgAt(eq, call, *s.argAt, s.Args[0])
+	}
+
+	return calledWithExactly(eq, call, s.Args...)
+}
+
+// setStub sets a response that the mock will return, keeping track of its use
+func (mock *Mock) setStub(key, methodName string, args []any, response MethodResponse) *Stub {
+	order := 0
+	for _, s := range mock.state().stubs {
+		if s.order >= order {
+			order = s.order + 1
+		}
+	}
+
+	s := &Stub{
+		MethodName: methodName,
+		Args:       args,
+		Response:   response,
+		order:      order,
+		origin:     callerLocation(),
+	}
+	mock.state().responses[key] = response
+	mock.state().stubs[key] = s
+
+	return s
+}
+
+// findStub finds the key of the response that the mock should return for a method call.
+//
+// The responses specified for the exact args have priority, followed by the responses specified
+// for args with argument matchers or for a single argument (the latest specified first), and then the method default response.
+//
+// The args are compared with the mock comparers, so the responses specified for args that are
+// only equal by a comparer (or by an Equal method) count as responses for the exact args
+func (mock *Mock) findStub(call MockCall) (string, bool) {
+	for _, args := range call.argForms() {
+		key := mountResponseKey(call.MethodName, args...)
+		if mock.hasResponse(key) {
+			return key, true
+		}
+	}
+
+	if key, s := mock.matchStub(call, false); s != nil && s.hasResponse() {
+		return key, true
+	}
+
+	if key, s := mock.matchStub(call, true); s != nil && s.hasResponse() {
+		return key, true
+	}
+
+	if mock.hasResponse(call.MethodName) {
+		return call.MethodName, true
+	}
+
+	return "", false
+}
+
+// matchStub finds the latest specified stub of the method whose args match the call args,
+// considering either the stubs with argument matchers (or for a single argument) or the stubs without them
+func (mock *Mock) matchStub(call MockCall, withMatchers bool) (key string, match *Stub) {
+	for k, s := range mock.state().stubs {
+		if s.MethodName != call.MethodName || s.Args == nil || s.usesMatchers() != withMatchers || (match != nil && s.order < match.order) {
+			continue
+		}
+
+		if s.matches(mock.state().equality, call) {
+			match = s
+			key = k
+		}
+	}
+
+	return
+}
+
+// hasResponse returns if there's a response specified with the key
+func (mock *Mock) hasResponse(key string) bool {
+	if s, ok := mock.state().stubs[key]; ok && s.lazy != nil {
+		return true
+	}
+
+	return !mock.state().responses[key].IsEmpty()
+}
+
+// hitStub marks the stub with the specified key as used
+func (mock *Mock) hitStub(key string) {
+	if s, ok := mock.state().stubs[key]; ok {
+		s.hits++
+	}
+}
+
+// UnusedStubs returns the responses that were specified on the mock, but were never returned,
+// in the order they were specified
+func (mock *Mock) UnusedStubs() []Stub {
+	unused := []Stub{}
+	for _, s := range mock.state().stubs {
+		if s.hits == 0 {
+			unused = append(unused, *s)
+		}
+	}
+
+	sort.Slice(unused, func(i, j int) bool {
+		return unused[i].order < unused[j].order
+	})
+
+	return unused
+}
+
+// UnmatchedCalls returns the mock calls that had no specified response to return.
+//
+// A call is checked against the responses specified when it was made,
+// so specifying a response afterwards doesn't hide an unmatched call
+func (mock *Mock) UnmatchedCalls() []MockCall {
+	s := mock.state()
+
+	unmatched := []MockCall{}
+	for i, call := range s.calls {
+		if !s.matched[s.callSeqs[i]] {
+			unmatched = append(unmatched, call)
+		}
+	}
+
+	return unmatched
+}
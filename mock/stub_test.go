@@ -0,0 +1,116 @@
+package mock
+
+import (
+	"fmt"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnusedStubs(t *testing.T) {
+	t.Run("Should return the stubs that were never used, in the order they were specified", func(t *testing.T) {
+		m := NewMock()
+		_, _, line, _ := runtime.Caller(0)
+		m.Method("GetUser").WithArgs("u1").Returns("user 1")
+		m.Method("GetUser").WithArgs("u2").Returns("user 2")
+		m.Method("GetUser").SetResponse("default user")
+		m.Method("Delete").SetResponse(nil)
+
+		m.GetResponseAndRegister("GetUser", "u2")
+
+		assert.Equal(t, []Stub{
+			{MethodName: "GetUser", Args: []any{"u1"}, Response: MethodResponse{"user 1"}, order: 0, origin: fmt.Sprintf("stub_test.go:%d", line+1)},
+			{MethodName: "GetUser", Response: MethodResponse{"default user"}, order: 2, origin: fmt.Sprintf("stub_test.go:%d", line+3)},
+			{MethodName: "Delete", Response: MethodResponse{nil}, order: 3, origin: fmt.Sprintf("stub_test.go:%d", line+4)},
+		}, m.UnusedStubs())
+
+		m.GetResponseAndRegister("GetUser", "u3")
+
+		unused := m.UnusedStubs()
+		assert.Equal(t, 2, len(unused))
+		assert.Equal(t, "GetUser", unused[0].MethodName)
+		assert.Equal(t, "Delete", unused[1].MethodName)
+	})
+	t.Run("Should return empty if every stub was used", func(t *testing.T) {
+		m := NewMock()
+		m.Method("GetUser").SetResponse("default user")
+
+		m.GetResponseAndRegister("GetUser", "u1")
+
+		assert.Empty(t, m.UnusedStubs())
+	})
+	t.Run("Should consider an overridden stub as a new stub", func(t *testing.T) {
+		m := NewMock()
+		m.Method("GetUser").SetResponse("default user")
+		m.GetResponseAndRegister("GetUser")
+
+		m.Method("GetUser").SetResponse("another user")
+
+		assert.Equal(t, 1, len(m.UnusedStubs()))
+	})
+}
+
+func TestUnmatchedCalls(t *testing.T) {
+	t.Run("Should return the calls that had no specified response", func(t *testing.T) {
+		m := NewMock()
+		m.Method("GetUser").WithArgs("u1").Returns("user 1")
+		m.Method("Delete").SetResponse(nil)
+		m.Method("Sum").CallThrough(func(a, b int) int { return a + b })
+
+		m.GetResponseAndRegister("GetUser", "u1")
+		m.GetResponseAndRegister("GetUser", "u2")
+		m.GetResponseAndRegister("Delete", "u1")
+		m.GetResponseAndRegister("Sum", 1, 2)
+		m.GetResponseAndRegister("Save", "u3")
+
+		unmatched := m.UnmatchedCalls()
+		assert.Equal(t, 2, len(unmatched))
+		assert.Equal(t, `GetUser("u2")`, formatCall(unmatched[0]))
+		assert.Equal(t, `Save("u3")`, formatCall(unmatched[1]))
+	})
+	t.Run("Should keep the calls that had no response when they were made", func(t *testing.T) {
+		m := NewMock()
+		m.GetResponseAndRegister("GetUser", "u1")
+		m.Method("GetUser").WithArgs("u1").Returns("user 1")
+		m.GetResponseAndRegister("GetUser", "u1")
+
+		unmatched := m.UnmatchedCalls()
+		assert.Equal(t, 1, len(unmatched))
+		assert.Equal(t, m.GetCalls()[0], unmatched[0])
+	})
+	t.Run("Should consider the registered calls answered by GetMethodResponse", func(t *testing.T) {
+		m := NewMock()
+		m.Method("GetUser").WithArgs("u1").Returns("user 1")
+
+		m.RegisterMethodCall("GetUser", "u1")
+		m.GetMethodResponse("GetUser", "u1")
+		m.RegisterMethodCall("GetUser", "u2")
+		m.GetMethodResponse("GetUser", "u2")
+		m.RegisterMethodCall("GetUser", "u1")
+
+		unmatched := m.UnmatchedCalls()
+		assert.Equal(t, 2, len(unmatched))
+		assert.Equal(t, `GetUser("u2")`, formatCall(unmatched[0]))
+		assert.Equal(t, `GetUser("u1")`, formatCall(unmatched[1]))
+		assert.Equal(t, m.GetCalls()[2], unmatched[1])
+	})
+	t.Run("Should return empty if the mock was not called", func(t *testing.T) {
+		m := NewMock()
+
+		assert.Empty(t, m.UnmatchedCalls())
+	})
+}
+
+func TestStubString(t *testing.T) {
+	t.Run("Should format a stub with args", func(t *testing.T) {
+		s := Stub{MethodName: "GetUser", Args: []any{"u1", 2}, Response: MethodResponse{"user", nil}}
+
+		assert.Equal(t, `GetUser("u1", 2) -> "user", <nil>`, s.String())
+	})
+	t.Run("Should format a default stub", func(t *testing.T) {
+		s := Stub{MethodName: "GetUser", Response: MethodResponse{"user"}}
+
+		assert.Equal(t, `GetUser (any arguments) -> "user"`, s.String())
+	})
+}
@@ -3,6 +3,8 @@ package mock
 import (
 	"fmt"
 	"reflect"
+	"sort"
+	"strings"
 )
 
 // mountResponseKey mounts the string key that should be used to access the mock responses map,
@@ -16,20 +18,30 @@ func mountResponseKey(name string, args ...any) (res string) {
 	return
 }
 
-// argsAreEqual matches two mock arguments to see if they are equal.
-// matchArg its the value to match.
-// usedArg its the argument that was actually used in the mock call
-func argsAreEqual(matchArg, usedArg any) bool {
-	matcher, ok := matchArg.(ArgumentMatcher)
-	if ok {
-		return matcher.Match(usedArg)
+// formatArgs formats a list of values in a readable way, separated by commas
+func formatArgs(args []any) string {
+	formatted := make([]string, len(args))
+	for i, arg := range args {
+		if s, ok := arg.(string); ok {
+			formatted[i] = fmt.Sprintf("%q", s)
+			continue
+		}
+
+		formatted[i] = fmt.Sprintf("%v", arg)
 	}
 
-	return reflect.DeepEqual(matchArg, usedArg)
+	return strings.Join(formatted, ", ")
 }
 
-// utility function to mount the error message when asserting mock or method calls
-func mountArgsAssertionErrMsg(title string, calls []MockCall, expectedArgs ...any) (msg string) {
+// formatCall formats a mock call in a readable way
+func formatCall(call MockCall) string {
+	return fmt.Sprintf("%s(%s)", call.MethodName, formatArgs(call.Args))
+}
+
+// utility function to mount the error message when asserting mock or method calls.
+//
+// If 'unmatched' is not nil, the expected args it returns for each call are listed below the call
+func mountArgsAssertionErrMsg(title string, calls []MockCall, unmatched func(call MockCall) []any, expectedArgs ...any) (msg string) {
 	msg = title
 
 	expectedArgsStr := ""
@@ -60,17 +72,100 @@ func mountArgsAssertionErrMsg(title string, calls []MockCall, expectedArgs ...an
 			t := reflect.TypeOf(callArg).String()
 			msg = fmt.Sprintf("%s  -- (%s) %v\n", msg, t, callArg)
 		}
+
+		if unmatched == nil {
+			continue
+		}
+		if args := unmatched(call); len(args) > 0 {
+			msg = fmt.Sprintf("%s  Unmatched expected arguments: %s\n", msg, formatArgs(args))
+		}
 	}
 
 	return
 }
 
+// mountCallListErrMsg mounts an assertion error message that lists the specified mock calls
+func mountCallListErrMsg(title string, calls []MockCall) (msg string) {
+	msg = title
+	for i, call := range calls {
+		msg = fmt.Sprintf("%s[%d]: %s\n", msg, i+1, formatCall(call))
+	}
+
+	return
+}
+
+// verifyAll it's a verification condition that matches every mock call
+func verifyAll(call MockCall) bool {
+	return true
+}
+
+// containsName returns if the name is on the list of names
+func containsName(names []string, name string) bool {
+	for _, n := range names {
+		if n == name {
+			return true
+		}
+	}
+
+	return false
+}
+
 // checkCalledWith it's a common implementation between the mock and method structs.
-// it checks if any of the mock or method calls have the specified arguments
-func checkCalledWith(calls []MockCall, args ...any) bool {
-	if len(args) == 0 {
-		for _, call := range calls {
-			if len(call.Args) == 0 {
+// it checks if any of the mock or method calls have the specified arguments,
+// where each call argument can match only one of the specified arguments
+func checkCalledWith(eq *equality, calls []MockCall, args ...any) bool {
+	for _, call := range calls {
+		if calledWith(eq, call, args...) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// calledWith returns if the call has the specified arguments, in any order
+func calledWith(eq *equality, call MockCall, args ...any) bool {
+	for _, callArgs := range call.argForms() {
+		if len(args) == 0 && len(callArgs) == 0 {
+			return true
+		}
+		if len(args) > 0 && len(unmatchedCallArgs(eq, callArgs, args)) == 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
+// unmatchedArgs returns the specified arguments that could not be matched to the call arguments,
+// considering the form of the call arguments that leaves the fewest of them unmatched (see MockCall.argForms)
+func unmatchedArgs(eq *equality, call MockCall, args ...any) []any {
+	return fewestUnmatched(call, func(callArgs []any) []any {
+		return unmatchedCallArgs(eq, callArgs, args)
+	})
+}
+
+// unmatchedCallArgs returns the specified arguments that could not be matched to the call arguments.
+//
+// The arguments are matched as a bipartite matching, so each call argument is matched at most once,
+// and an argument is only left unmatched if there's no way of matching it along with the others
+func unmatchedCallArgs(eq *equality, callArgs, args []any) []any {
+	// matchedBy holds, for each call argument, the index of the specified argument matched to it
+	matchedBy := make([]int, len(callArgs))
+	for i := range matchedBy {
+		matchedBy[i] = -1
+	}
+
+	var match func(argN int, visited []bool) bool
+	match = func(argN int, visited []bool) bool {
+		for i, callArg := range callArgs {
+			if visited[i] || !eq.argsAreEqual(args[argN], callArg) {
+				continue
+			}
+			visited[i] = true
+
+			if matchedBy[i] < 0 || match(matchedBy[i], visited) {
+				matchedBy[i] = argN
 				return true
 			}
 		}
@@ -78,16 +173,97 @@ func checkCalledWith(calls []MockCall, args ...any) bool {
 		return false
 	}
 
+	unmatched := []any{}
+	for argN, arg := range args {
+		if !match(argN, make([]bool, len(callArgs))) {
+			unmatched = append(unmatched, arg)
+		}
+	}
+
+	return unmatched
+}
+
+// fewestUnmatched returns the fewest unmatched arguments between the forms of the call arguments
+func fewestUnmatched(call MockCall, unmatched func(callArgs []any) []any) (fewest []any) {
+	for i, callArgs := range call.argForms() {
+		if u := unmatched(callArgs); i == 0 || len(u) < len(fewest) {
+			fewest = u
+		}
+	}
+
+	return
+}
+
+// checkCalledWithInOrder it's a common implementation between the mock and method structs.
+// it checks if any of the mock or method calls have the specified arguments in the same order,
+// not necessarily next to each other
+func checkCalledWithInOrder(eq *equality, calls []MockCall, args ...any) bool {
 	for _, call := range calls {
-		hasArgs := true
-		for _, arg := range args {
-			if !call.HasArgument(arg) {
-				hasArgs = false
-				break
-			}
+		if calledWithInOrder(eq, call, args...) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// calledWithInOrder returns if the call has the specified arguments in the same order
+func calledWithInOrder(eq *equality, call MockCall, args ...any) bool {
+	for _, callArgs := range call.argForms() {
+		if len(args) == 0 && len(callArgs) == 0 {
+			return true
+		}
+		if len(args) > 0 && len(unmatchedCallArgsInOrder(eq, callArgs, args)) == 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
+// unmatchedArgsInOrder returns the specified arguments that could not be matched to the call arguments
+// in the same order, starting from the first specified argument that's out of order
+func unmatchedArgsInOrder(eq *equality, call MockCall, args ...any) []any {
+	return fewestUnmatched(call, func(callArgs []any) []any {
+		return unmatchedCallArgsInOrder(eq, callArgs, args)
+	})
+}
+
+// unmatchedCallArgsInOrder returns the specified arguments that could not be matched to the call arguments
+// in the same order, starting from the first specified argument that's out of order
+func unmatchedCallArgsInOrder(eq *equality, callArgs, args []any) []any {
+	next := 0
+	for argN, arg := range args {
+		matched := false
+		for next < len(callArgs) && !matched {
+			matched = eq.argsAreEqual(arg, callArgs[next])
+			next++
 		}
 
-		if hasArgs {
+		if !matched {
+			return args[argN:]
+		}
+	}
+
+	return []any{}
+}
+
+// checkCalledWithArgAt it's a common implementation between the mock and method structs.
+// it checks if any of the mock or method calls have the specified argument at the position 'i'
+func checkCalledWithArgAt(eq *equality, calls []MockCall, i int, arg any) bool {
+	for _, call := range calls {
+		if calledWithArgAt(eq, call, i, arg) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// calledWithArgAt returns if the call has the specified argument at the position 'i'
+func calledWithArgAt(eq *equality, call MockCall, i int, arg any) bool {
+	for _, callArgs := range call.argForms() {
+		if i >= 0 && i < len(callArgs) && eq.argsAreEqual(arg, callArgs[i]) {
 			return true
 		}
 	}
@@ -97,34 +273,260 @@ func checkCalledWith(calls []MockCall, args ...any) bool {
 
 // checkCalledWithExactly it's a common implementation between the mock and method structs.
 // it checks if any of the mock or method calls have exactly the specified arguments
-func checkCalledWithExactly(calls []MockCall, args ...any) bool {
-	if len(args) == 0 {
-		for _, call := range calls {
-			if len(call.Args) == 0 {
-				return true
-			}
+func checkCalledWithExactly(eq *equality, calls []MockCall, args ...any) bool {
+	for _, call := range calls {
+		if calledWithExactly(eq, call, args...) {
+			return true
 		}
+	}
+
+	return false
+}
 
+// calledWithExactly returns if the call has exactly the specified arguments, in the same order
+func calledWithExactly(eq *equality, call MockCall, args ...any) bool {
+	for _, callArgs := range call.argForms() {
+		if exactArgs(eq, callArgs, args) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// exactArgs returns if the call arguments are exactly the specified arguments, in the same order.
+//
+// When the last specified argument is a variadic matcher (see MatchVariadic),
+// it's matched against every call argument that's left
+func exactArgs(eq *equality, callArgs, args []any) bool {
+	if n := len(args); n > 0 && len(callArgs) >= n-1 {
+		vm, ok := args[n-1].(VariadicMatcher)
+		if ok && exactArgs(eq, callArgs[:n-1], args[:n-1]) && vm.matchTail(eq, callArgs[n-1:]) {
+			return true
+		}
+	}
+
+	if len(args) != len(callArgs) {
 		return false
 	}
 
+	for i, callArg := range callArgs {
+		if !eq.argsAreEqual(args[i], callArg) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// returnedCalls returns the calls that had their response recorded
+func returnedCalls(calls []MockCall) []MockCall {
+	returned := []MockCall{}
 	for _, call := range calls {
-		if len(args) != len(call.Args) {
-			continue
+		if call.returned {
+			returned = append(returned, call)
 		}
+	}
 
-		hasExactArgs := true
-		for i, callArg := range call.Args {
-			if !argsAreEqual(args[i], callArg) {
-				hasExactArgs = false
-				break
-			}
+	return returned
+}
+
+// returnedWith returns if the call response was exactly the specified values,
+// with the same values and in the same order
+func returnedWith(eq *equality, call MockCall, values ...any) bool {
+	if !call.returned || len(call.Response) != len(values) {
+		return false
+	}
+
+	for i, val := range call.Response {
+		if !eq.argsAreEqual(values[i], val) {
+			return false
 		}
+	}
+
+	return true
+}
 
-		if hasExactArgs {
+// checkReturnedWith it's a common implementation between the mock and method structs.
+// it checks if any of the mock or method calls returned exactly the specified values
+func checkReturnedWith(eq *equality, calls []MockCall, values ...any) bool {
+	for _, call := range calls {
+		if returnedWith(eq, call, values...) {
 			return true
 		}
 	}
 
 	return false
 }
+
+// checkNthReturnedWith it's a common implementation between the mock and method structs.
+// it checks if the 'n'th mock or method call returned exactly the specified values
+func checkNthReturnedWith(eq *equality, calls []MockCall, n int, values ...any) bool {
+	if n < 1 || n > len(calls) {
+		return false
+	}
+
+	return returnedWith(eq, calls[n-1], values...)
+}
+
+// mountResponsesAssertionErrMsg mounts an assertion error message that lists the responses of the specified mock calls
+func mountResponsesAssertionErrMsg(title string, calls []MockCall) (msg string) {
+	msg = title
+	if len(calls) == 0 {
+		msg = fmt.Sprintf("%s\nBut it was not called\n", msg)
+		return
+	}
+
+	msg = fmt.Sprintf("%s\nActual responses:\n", msg)
+	for i, call := range calls {
+		if !call.returned {
+			msg = fmt.Sprintf("%s[%d]: %s (no recorded response)\n", msg, i+1, formatCall(call))
+			continue
+		}
+
+		msg = fmt.Sprintf("%s[%d]: %s -> (%s)\n", msg, i+1, formatCall(call), formatArgs(call.Response))
+	}
+
+	return
+}
+
+// formatTimes formats a number of times in a readable way
+func formatTimes(n int) string {
+	if n == 1 {
+		return "once"
+	}
+
+	return fmt.Sprintf("%d times", n)
+}
+
+// callThrough calls the real implementation of a mock method with the specified args,
+// returning its results as a method response
+func callThrough(name string, fn reflect.Value, args ...any) MethodResponse {
+	fnType := fn.Type()
+
+	validArgsLen := len(args) == fnType.NumIn()
+	if fnType.IsVariadic() {
+		validArgsLen = len(args) >= fnType.NumIn()-1
+	}
+	if !validArgsLen {
+		msg := fmt.Sprintf("Tried to call through the mock method %s with %d arguments, but the real implementation receives %d", name, len(args), fnType.NumIn())
+		panic(msg)
+	}
+
+	// the variadic arguments can be passed spread, or as a single slice argument
+	variadicSlice := fnType.IsVariadic() &&
+		len(args) == fnType.NumIn() &&
+		reflect.TypeOf(args[len(args)-1]) == fnType.In(fnType.NumIn()-1)
+
+	in := make([]reflect.Value, len(args))
+	for i, arg := range args {
+		argType := argumentType(fnType, i)
+		if variadicSlice && i == len(args)-1 {
+			argType = fnType.In(i)
+		}
+
+		val, ok := assignableValue(arg, argType)
+		if !ok {
+			msg := fmt.Sprintf("Tried to call through the mock method %s with a %T value on the argument %d, but the real implementation expects a %s", name, arg, i, argType)
+			panic(msg)
+		}
+		in[i] = val
+	}
+
+	var out []reflect.Value
+	if variadicSlice {
+		out = fn.CallSlice(in)
+	} else {
+		out = fn.Call(in)
+	}
+
+	res := make(MethodResponse, len(out))
+	for i, val := range out {
+		res[i] = val.Interface()
+	}
+
+	return res
+}
+
+// argumentType returns the type of the 'i' argument of a function type,
+// considering the element type of the variadic argument
+func argumentType(fnType reflect.Type, i int) reflect.Type {
+	if fnType.IsVariadic() && i >= fnType.NumIn()-1 {
+		return fnType.In(fnType.NumIn() - 1).Elem()
+	}
+
+	return fnType.In(i)
+}
+
+// assignableValue returns the value as a reflect value of the type t,
+// and if the value is assignable to that type.
+//
+// A nil value is converted to the zero value of the type
+func assignableValue(val any, t reflect.Type) (reflect.Value, bool) {
+	if val == nil {
+		return reflect.Zero(t), true
+	}
+
+	rv := reflect.ValueOf(val)
+	if !rv.Type().AssignableTo(t) {
+		return reflect.Value{}, false
+	}
+
+	out := reflect.New(t).Elem()
+	out.Set(rv)
+
+	return out, true
+}
+
+// closestName returns the name closest to the specified name, between the candidates.
+//
+// An empty string is returned if none of the candidates is reasonably close
+func closestName(name string, candidates []string) (closest string) {
+	sort.Strings(candidates)
+
+	best := -1
+	for _, candidate := range candidates {
+		d := levenshtein(strings.ToLower(name), strings.ToLower(candidate))
+		if d > len(candidate)/2 {
+			continue
+		}
+
+		if best < 0 || d < best {
+			best = d
+			closest = candidate
+		}
+	}
+
+	return
+}
+
+// levenshtein returns the edit distance between two strings
+func levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+
+	prev := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		curr := make([]int, len(rb)+1)
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = prev[j-1] + cost
+			if prev[j]+1 < curr[j] {
+				curr[j] = prev[j] + 1
+			}
+			if curr[j-1]+1 < curr[j] {
+				curr[j] = curr[j-1] + 1
+			}
+		}
+		prev = curr
+	}
+
+	return prev[len(rb)]
+}